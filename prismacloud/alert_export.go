@@ -0,0 +1,559 @@
+package prismacloud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/paloaltonetworks/prisma-cloud-go/alert"
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Alert export modes set with the alert_export_mode connection option
+const (
+	alertExportModeSync  = "sync"
+	alertExportModeAsync = "async"
+)
+
+// Polling of the alert CSV jobs. These are variables so tests can shorten them.
+var (
+	alertExportPollInterval = 5 * time.Second
+	alertExportTimeout      = 30 * time.Minute
+)
+
+// Number of parsed alerts between two progress log lines
+const alertExportLogEvery = 10000
+
+// alertExportState is persisted next to the spooled CSV, so an interrupted export
+// resumes from the submitted job instead of starting a new one
+type alertExportState struct {
+	JobId       string    `json:"job_id"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+}
+
+// alertExportMode returns the alert export mode of the connection, sync by default
+func alertExportMode(d *plugin.QueryData) (string, error) {
+	config := GetConfig(d.Connection)
+	if config.AlertExportMode == nil || *config.AlertExportMode == "" {
+		return alertExportModeSync, nil
+	}
+
+	mode := strings.ToLower(*config.AlertExportMode)
+	if mode != alertExportModeSync && mode != alertExportModeAsync {
+		return "", fmt.Errorf("invalid alert_export_mode '%s', expected 'sync' or 'async'", *config.AlertExportMode)
+	}
+	return mode, nil
+}
+
+// alertExportSpoolDir returns the directory of the spooled alert CSVs of the connection.
+// It defaults to the cache directory of the user, since the exports hold alert data.
+func alertExportSpoolDir(d *plugin.QueryData) (string, error) {
+	config := GetConfig(d.Connection)
+	if config.AlertExportSpoolDir != nil && *config.AlertExportSpoolDir != "" {
+		dir := *config.AlertExportSpoolDir
+		if strings.HasPrefix(dir, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				dir = filepath.Join(home, dir[2:])
+			}
+		}
+		return dir, nil
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the user cache directory, set alert_export_spool_dir in the connection configuration: %v", err)
+	}
+	return filepath.Join(cacheDir, "steampipe-plugin-prismacloud", "alert_export"), nil
+}
+
+// alertExportSpoolTtl returns how long a completed export is reused by later queries.
+// It defaults to the query cache TTL, so exported alerts are never older than cached ones.
+func alertExportSpoolTtl(d *plugin.QueryData) (time.Duration, error) {
+	config := GetConfig(d.Connection)
+	if config.AlertExportSpoolTtl != nil {
+		if *config.AlertExportSpoolTtl < 0 {
+			return 0, fmt.Errorf("invalid alert_export_spool_ttl %d, expected a number of seconds", *config.AlertExportSpoolTtl)
+		}
+		return time.Duration(*config.AlertExportSpoolTtl) * time.Second, nil
+	}
+	if d.QueryContext != nil && d.QueryContext.CacheEnabled {
+		return time.Duration(d.QueryContext.CacheTTL) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// alertExportRetention returns how long the spool of an export is kept. An interrupted export
+// is resumed until its job times out, even when completed exports aren't reused.
+func alertExportRetention(ttl time.Duration) time.Duration {
+	if ttl > alertExportTimeout {
+		return ttl
+	}
+	return alertExportTimeout
+}
+
+// Columns of the table the alert CSV doesn't hold
+var alertExportUnavailableColumns = []string{"alert_count", "history", "investigate_options", "policy_system_default", "resource", "risk_detail"}
+
+// checkAlertExportColumns returns an error when the query selects a column the alert CSV doesn't hold,
+// rather than returning it empty
+func checkAlertExportColumns(d *plugin.QueryData) error {
+	var unavailable []string
+	for _, column := range d.QueryContext.Columns {
+		for _, name := range alertExportUnavailableColumns {
+			if column == name {
+				unavailable = append(unavailable, column)
+			}
+		}
+	}
+	if len(unavailable) > 0 {
+		return fmt.Errorf("%s can't be read with alert_export_mode 'async', remove them from the query or set alert_export_mode to 'sync'", strings.Join(unavailable, ", "))
+	}
+	return nil
+}
+
+// listPrismacloudAlertsExport streams the alerts matching req from an asynchronous alert CSV job.
+// The job is submitted, polled until the CSV is ready, and the CSV is downloaded to the spool directory before it is parsed.
+func listPrismacloudAlertsExport(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, req alert.Request) error {
+	logger := plugin.Logger(ctx)
+
+	if err := checkAlertExportColumns(d); err != nil {
+		return err
+	}
+	ttl, err := alertExportSpoolTtl(d)
+	if err != nil {
+		return err
+	}
+
+	// The export holds every matching alert, so paging isn't used
+	req.Limit = 0
+	req.Offset = 0
+	req.PageToken = ""
+
+	// An alert_time range without an upper bound ends now, and its start usually moves with now() too.
+	// The start is rounded down to the spool TTL so the export can be resumed and reused by later runs of the query.
+	// The export may then hold older alerts, which the alert_time quals filter out.
+	_, end := timeRangeFromQuals(d, "alert_time")
+	openEnded := false
+	if v, ok := req.TimeRange.Value.(timerange.Absolute); ok && end == 0 && ttl > 0 {
+		openEnded = true
+		v.Start = int(time.UnixMilli(int64(v.Start)).Truncate(ttl).UnixMilli())
+		req.TimeRange.Value = v
+	}
+
+	dir, err := alertExportSpoolDir(d)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create alert_export_spool_dir %s: %v", dir, err)
+	}
+	removeExpiredAlertExports(ctx, dir, alertExportRetention(ttl))
+
+	key, err := alertExportKey(d, req, openEnded)
+	if err != nil {
+		return err
+	}
+	statePath := filepath.Join(dir, key+".json")
+	csvPath := filepath.Join(dir, key+".csv")
+
+	// Concurrent queries of the same export wait for the first one instead of downloading it again
+	unlock := lockAlertExport(statePath)
+	state := readAlertExportState(statePath)
+	if state != nil {
+		age := time.Since(state.SubmittedAt)
+		if (!state.CompletedAt.IsZero() && age > ttl) || age > alertExportRetention(ttl) {
+			logger.Debug("prismacloud_alert.listPrismacloudAlertsExport", "expired_job", state.JobId)
+			state = nil
+		}
+	}
+
+	if state == nil || state.CompletedAt.IsZero() {
+		state, err = runAlertExportJob(ctx, d, conn, req, state, statePath, csvPath)
+		if err != nil {
+			unlock()
+			return err
+		}
+	} else {
+		logger.Info("prismacloud_alert.listPrismacloudAlertsExport", "reusing_job", state.JobId, "completed_at", state.CompletedAt)
+	}
+
+	// The CSV is opened before unlocking, so a later export replacing it doesn't affect this query
+	f, err := os.Open(csvPath)
+	unlock()
+	if err != nil {
+		return fmt.Errorf("failed to open the spooled alert csv %s: %v", csvPath, err)
+	}
+	defer f.Close()
+
+	count := 0
+	err = parseAlertCsv(f, func(a model.Alert) bool {
+		d.StreamListItem(ctx, a)
+		count++
+		if count%alertExportLogEvery == 0 {
+			logger.Info("prismacloud_alert.listPrismacloudAlertsExport", "job", state.JobId, "parsed_alerts", count)
+		}
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse the alert csv of job %s: %v", state.JobId, err)
+	}
+	logger.Info("prismacloud_alert.listPrismacloudAlertsExport", "job", state.JobId, "parsed_alerts", count)
+
+	return nil
+}
+
+// runAlertExportJob submits a job, or resumes the given one, waits for its CSV and downloads it to csvPath
+func runAlertExportJob(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, req alert.Request, state *alertExportState, statePath, csvPath string) (*alertExportState, error) {
+	logger := plugin.Logger(ctx)
+
+	resumed := state != nil
+	if !resumed {
+		if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+			return nil, err
+		}
+		job, err := api.SubmitAlertCsvJob(conn, req)
+		if err != nil {
+			logger.Error("prismacloud_alert.runAlertExportJob", "api_error", err)
+			return nil, err
+		}
+		state = &alertExportState{JobId: job.Id, SubmittedAt: time.Now()}
+		if err := writeAlertExportState(statePath, state); err != nil {
+			return nil, err
+		}
+		logger.Info("prismacloud_alert.runAlertExportJob", "submitted_job", state.JobId)
+	} else {
+		logger.Info("prismacloud_alert.runAlertExportJob", "resuming_job", state.JobId, "submitted_at", state.SubmittedAt)
+	}
+
+	// Poll the job until its CSV is ready
+	start := time.Now()
+	for {
+		if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+			return nil, err
+		}
+		job, err := api.GetAlertCsvJobStatus(conn, state.JobId)
+		if err != nil {
+			// The job of an interrupted export may have been purged, so it is submitted again
+			if resumed && strings.Contains(err.Error(), "object not found") {
+				os.Remove(statePath)
+				return runAlertExportJob(ctx, d, conn, req, nil, statePath, csvPath)
+			}
+			logger.Error("prismacloud_alert.runAlertExportJob", "api_error", err)
+			return nil, err
+		}
+
+		status := strings.ToUpper(job.Status)
+		logger.Info("prismacloud_alert.runAlertExportJob", "job", state.JobId, "status", status, "elapsed", time.Since(start).Round(time.Second).String())
+		if status == "READY_TO_DOWNLOAD" || status == "COMPLETED" {
+			break
+		}
+		if status == "FAILED" || status == "CANCELLED" {
+			os.Remove(statePath)
+			return nil, fmt.Errorf("alert csv job %s ended with status %s", state.JobId, job.Status)
+		}
+		if time.Since(start) > alertExportTimeout {
+			return nil, fmt.Errorf("alert csv job %s is not ready after %s, run the query again to resume waiting for it", state.JobId, alertExportTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(alertExportPollInterval):
+		}
+	}
+
+	// Download to a temporary file first, so a partial download is never parsed
+	if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+		return nil, err
+	}
+	partPath := csvPath + ".part"
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the alert csv spool %s: %v", partPath, err)
+	}
+	n, err := api.DownloadAlertCsv(conn, state.JobId, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.Error("prismacloud_alert.runAlertExportJob", "download_error", err)
+		return nil, err
+	}
+	if err := os.Rename(partPath, csvPath); err != nil {
+		return nil, err
+	}
+	logger.Info("prismacloud_alert.runAlertExportJob", "job", state.JobId, "downloaded_bytes", n)
+
+	state.CompletedAt = time.Now()
+	if err := writeAlertExportState(statePath, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// alertExportKey identifies the spool of an alert request on a connection.
+// The end of an open-ended time range is stored as "now", so the key doesn't change with the time of the query.
+func alertExportKey(d *plugin.QueryData, req alert.Request, openEnded bool) (string, error) {
+	var timeRange interface{} = req.TimeRange.Value
+	if v, ok := req.TimeRange.Value.(timerange.Absolute); ok && openEnded {
+		timeRange = map[string]interface{}{"startTime": v.Start, "endTime": "now"}
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"filters":   req.Filters,
+		"timeRange": timeRange,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	if d.Connection != nil {
+		h.Write([]byte(d.Connection.Name))
+	}
+	h.Write([]byte{0})
+	h.Write(b)
+
+	return fmt.Sprintf("alerts-%x", h.Sum(nil)[:12]), nil
+}
+
+// Exports in progress in this process, by state path, with the number of queries waiting on each
+var (
+	alertExportLocksMutex sync.Mutex
+	alertExportLocks      = map[string]*alertExportLock{}
+)
+
+type alertExportLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lockAlertExport locks the export of the given state path, returning the function unlocking it
+func lockAlertExport(statePath string) func() {
+	alertExportLocksMutex.Lock()
+	l, ok := alertExportLocks[statePath]
+	if !ok {
+		l = &alertExportLock{}
+		alertExportLocks[statePath] = l
+	}
+	l.refs++
+	alertExportLocksMutex.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		alertExportLocksMutex.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(alertExportLocks, statePath)
+		}
+		alertExportLocksMutex.Unlock()
+	}
+}
+
+// tryLockAlertExport locks the export of the given state path unless a query is using it
+func tryLockAlertExport(statePath string) (func(), bool) {
+	alertExportLocksMutex.Lock()
+	_, inUse := alertExportLocks[statePath]
+	alertExportLocksMutex.Unlock()
+	if inUse {
+		return nil, false
+	}
+	return lockAlertExport(statePath), true
+}
+
+// removeExpiredAlertExports deletes the spooled exports of the directory older than retention,
+// skipping the exports used by a query
+func removeExpiredAlertExports(ctx context.Context, dir string, retention time.Duration) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	// The files of an export share the key of the export as their name
+	lastModified := map[string]time.Time{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "alerts-") || entry.IsDir() {
+			continue
+		}
+		key := strings.TrimSuffix(strings.TrimSuffix(name, ".part"), filepath.Ext(strings.TrimSuffix(name, ".part")))
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(lastModified[key]) {
+			lastModified[key] = info.ModTime()
+		}
+	}
+
+	for key, modTime := range lastModified {
+		statePath := filepath.Join(dir, key+".json")
+		expiresAt := modTime
+		if state := readAlertExportState(statePath); state != nil {
+			expiresAt = state.SubmittedAt
+		}
+		if time.Since(expiresAt) <= retention {
+			continue
+		}
+
+		unlock, ok := tryLockAlertExport(statePath)
+		if !ok {
+			continue
+		}
+		for _, path := range []string{statePath, filepath.Join(dir, key+".csv"), filepath.Join(dir, key+".csv.part")} {
+			os.Remove(path)
+		}
+		unlock()
+		plugin.Logger(ctx).Debug("prismacloud_alert.removeExpiredAlertExports", "removed_export", key)
+	}
+}
+
+func readAlertExportState(path string) *alertExportState {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var state alertExportState
+	if err := json.Unmarshal(b, &state); err != nil || state.JobId == "" {
+		return nil
+	}
+	return &state
+}
+
+func writeAlertExportState(path string, state *alertExportState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write the alert export state %s: %v", path, err)
+	}
+	return nil
+}
+
+// Setters of the alert fields for the columns of the alert CSV, by lower case header
+var alertCsvColumns = map[string]func(a *model.Alert, value string){
+	"alert id":         func(a *model.Alert, v string) { a.Id = v },
+	"status":           func(a *model.Alert, v string) { a.Status = strings.ToLower(v) },
+	"policy id":        func(a *model.Alert, v string) { a.Policy.Id = v },
+	"policy name":      func(a *model.Alert, v string) { a.Policy.Name = v },
+	"policy type":      func(a *model.Alert, v string) { a.Policy.Type = strings.ToLower(v) },
+	"severity":         func(a *model.Alert, v string) { a.Policy.Severity = strings.ToLower(v) },
+	"remediable":       func(a *model.Alert, v string) { a.Policy.Remediable, _ = strconv.ParseBool(v) },
+	"resource name":    func(a *model.Alert, v string) { a.Resource.Name = v },
+	"resource id":      func(a *model.Alert, v string) { a.Resource.Id = v },
+	"resource type":    func(a *model.Alert, v string) { a.Resource.ResourceType = v },
+	"resource rrn":     func(a *model.Alert, v string) { a.Resource.Rrn = v },
+	"cloud type":       func(a *model.Alert, v string) { a.Resource.CloudType = strings.ToLower(v) },
+	"account":          func(a *model.Alert, v string) { a.Resource.Account = v },
+	"account id":       func(a *model.Alert, v string) { a.Resource.AccountId = v },
+	"region":           func(a *model.Alert, v string) { a.Resource.Region = v },
+	"region id":        func(a *model.Alert, v string) { a.Resource.RegionId = v },
+	"dismissed by":     func(a *model.Alert, v string) { a.DismissedBy = v },
+	"dismissal reason": func(a *model.Alert, v string) { a.DismissalNote = v },
+	"dismissal note":   func(a *model.Alert, v string) { a.DismissalNote = v },
+	"triggered by":     func(a *model.Alert, v string) { a.TriggeredBy = v },
+}
+
+// Time fields of the alert for the time columns of the alert CSV, by lower case header
+var alertCsvTimeColumns = map[string]func(a *model.Alert) *int64{
+	"alert time":     func(a *model.Alert) *int64 { return &a.AlertTime },
+	"first seen":     func(a *model.Alert) *int64 { return &a.FirstSeen },
+	"last seen":      func(a *model.Alert) *int64 { return &a.LastSeen },
+	"event occurred": func(a *model.Alert) *int64 { return &a.EventOccurred },
+}
+
+// parseAlertCsv reads the alerts of an alert CSV, calling fn for each alert until it returns false.
+// Columns without a matching alert field are ignored, and a time that can't be parsed is an error.
+func parseAlertCsv(r io.Reader, fn func(model.Alert) bool) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	setters := make([]func(*model.Alert, string), len(header))
+	timeFields := make([]func(*model.Alert) *int64, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		setters[i] = alertCsvColumns[name]
+		timeFields[i] = alertCsvTimeColumns[name]
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var a model.Alert
+		for i, value := range record {
+			if i >= len(header) || value == "" {
+				continue
+			}
+			if setters[i] != nil {
+				setters[i](&a, value)
+			}
+			if timeFields[i] != nil {
+				ms, ok := parseAlertCsvTime(value)
+				if !ok {
+					line, _ := reader.FieldPos(i)
+					return fmt.Errorf("line %d: invalid %s %q", line, strings.TrimPrefix(header[i], "\ufeff"), value)
+				}
+				*timeFields[i](&a) = ms
+			}
+		}
+		if !fn(a) {
+			return nil
+		}
+	}
+}
+
+// Time formats seen in the alert CSV
+var alertCsvTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"Jan 2, 2006 at 3:04:05 PM MST",
+	"Jan 2, 2006 at 3:04:05 PM",
+}
+
+// parseAlertCsvTime returns a CSV time in Unix milliseconds, or false when its format is unknown
+func parseAlertCsvTime(value string) (int64, bool) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ms, true
+	}
+	for _, layout := range alertCsvTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UnixMilli(), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,335 @@
+package prismacloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+const testAlertCsv = "\ufeffAlert ID,Status,Policy Name,Severity,Account ID,Region,Resource Name,Alert Time,Unknown Column\n" +
+	"P-1,OPEN,AWS S3 bucket publicly readable,High,123456789012,AWS Virginia,bucket-a,2024-05-01T10:00:00Z,x\n" +
+	"P-2,Resolved,\"AWS security group allows all traffic, including SSH\",Medium,210987654321,AWS Oregon,sg-1,1714557600000,y\n"
+
+// newAsyncExportTestPlugin starts the plugin in async alert export mode, reusing exports for an hour, and returns a function querying the alerts,
+// for all time unless quals are given, along with the spool directory
+func newAsyncExportTestPlugin(t *testing.T, m *mockServer) (func(...*proto.Qual) ([]map[string]interface{}, error), string) {
+	t.Helper()
+
+	interval := alertExportPollInterval
+	alertExportPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { alertExportPollInterval = interval })
+
+	dir := t.TempDir()
+	server := newTestPlugin(t, m, fmt.Sprintf(`
+alert_export_mode      = "async"
+alert_export_spool_dir = %q
+alert_export_spool_ttl = 3600
+`, dir))
+
+	return func(qs ...*proto.Qual) ([]map[string]interface{}, error) {
+		if len(qs) == 0 {
+			qs = []*proto.Qual{stringQual("time_range", "=", "epoch")}
+		}
+		rows, err := testQuery{
+			table:   "prismacloud_alert",
+			columns: []string{"id", "status", "policy_name", "policy_severity", "cloud_account_id", "alert_time"},
+			quals:   quals(qs...),
+		}.execute(t, server)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]map[string]interface{}, 0, len(rows))
+		for _, row := range rows {
+			res = append(res, map[string]interface{}{
+				"id":               row["id"].GetStringValue(),
+				"status":           row["status"].GetStringValue(),
+				"policy_name":      row["policy_name"].GetStringValue(),
+				"policy_severity":  row["policy_severity"].GetStringValue(),
+				"cloud_account_id": row["cloud_account_id"].GetStringValue(),
+				"alert_time":       row["alert_time"].GetTimestampValue().AsTime(),
+			})
+		}
+		return res, nil
+	}, dir
+}
+
+func TestListAlertsAsyncExport(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/alert/csv", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "IN_PROGRESS"}
+	})
+	var polls int32
+	m.handle("GET", "/alert/csv/csv-1/status", func(r mockRequest) (int, interface{}) {
+		if atomic.AddInt32(&polls, 1) < 3 {
+			return http.StatusOK, map[string]string{"id": "csv-1", "status": "IN_PROGRESS"}
+		}
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/download", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testAlertCsv)
+	})
+	query, _ := newAsyncExportTestPlugin(t, m)
+
+	rows, err := query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(rows))
+	}
+	byId := map[string]map[string]interface{}{}
+	for _, row := range rows {
+		byId[row["id"].(string)] = row
+	}
+	if p1 := byId["P-1"]; p1["status"] != "open" || p1["policy_severity"] != "high" || p1["cloud_account_id"] != "123456789012" {
+		t.Errorf("unexpected alert P-1: %v", p1)
+	}
+	if got := byId["P-1"]["alert_time"].(time.Time); !got.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected alert time: %v", got)
+	}
+	if p2 := byId["P-2"]; !strings.Contains(p2["policy_name"].(string), "including SSH") || p2["alert_time"].(time.Time).UnixMilli() != 1714557600000 {
+		t.Errorf("unexpected alert P-2: %v", p2)
+	}
+
+	if n := len(m.received("POST", "/v2/alert")); n != 0 {
+		t.Errorf("expected no paged alert requests, got %d", n)
+	}
+	submits := m.received("POST", "/alert/csv")
+	if len(submits) != 1 {
+		t.Fatalf("expected 1 submitted job, got %d", len(submits))
+	}
+	if _, ok := submits[0].Body["limit"]; ok {
+		t.Error("expected the export request to have no limit")
+	}
+	if polls < 3 {
+		t.Errorf("expected the job status to be polled until ready, got %d polls", polls)
+	}
+}
+
+func TestListAlertsAsyncExportResumes(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/alert/csv", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "IN_PROGRESS"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/status", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	var downloads int32
+	m.handle("GET", "/alert/csv/csv-1/download", func(r mockRequest) (int, interface{}) {
+		if atomic.AddInt32(&downloads, 1) == 1 {
+			return http.StatusBadGateway, []byte("upstream timeout")
+		}
+		return http.StatusOK, []byte(testAlertCsv)
+	})
+	query, _ := newAsyncExportTestPlugin(t, m)
+
+	if _, err := query(); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected the first download to fail, got %v", err)
+	}
+
+	rows, err := query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(rows))
+	}
+	if n := len(m.received("POST", "/alert/csv")); n != 1 {
+		t.Errorf("expected the interrupted job to be resumed instead of submitting a new one, got %d submits", n)
+	}
+
+	// A completed export is read from the spool
+	if _, err := query(); err != nil {
+		t.Fatal(err)
+	}
+	if downloads != 2 {
+		t.Errorf("expected the spooled csv to be reused, got %d downloads", downloads)
+	}
+}
+
+func TestListAlertsAsyncExportOpenEndedTimeRange(t *testing.T) {
+	m := newMockServer(t)
+	var submits int32
+	m.handle("POST", "/alert/csv", func(r mockRequest) (int, interface{}) {
+		atomic.AddInt32(&submits, 1)
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "IN_PROGRESS"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/status", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	var downloads int32
+	m.handle("GET", "/alert/csv/csv-1/download", func(r mockRequest) (int, interface{}) {
+		atomic.AddInt32(&downloads, 1)
+		time.Sleep(100 * time.Millisecond)
+		return http.StatusOK, []byte(testAlertCsv)
+	})
+	query, _ := newAsyncExportTestPlugin(t, m)
+
+	// Queries such as alert_time > now() - interval '7 days' have a slightly different start on every run
+	since := time.Now().Add(-7 * 24 * time.Hour).Truncate(time.Hour).Add(time.Minute)
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, start := range []time.Time{since, since.Add(time.Second)} {
+		wg.Add(1)
+		go func(start time.Time) {
+			defer wg.Done()
+			_, err := query(timestampQual("alert_time", ">", start))
+			errs <- err
+		}(start)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if submits != 1 || downloads != 1 {
+		t.Errorf("expected the queries to share a single export, got %d submits and %d downloads", submits, downloads)
+	}
+	requests := m.received("POST", "/alert/csv")
+	if len(requests) == 0 {
+		t.Fatal("expected an export to be submitted")
+	}
+	value := requests[0].Body["timeRange"].(map[string]interface{})["value"].(map[string]interface{})
+	if value["startTime"] != float64(since.Truncate(time.Hour).UnixMilli()) {
+		t.Errorf("expected the export to start at the start of the hour, got %v", value["startTime"])
+	}
+}
+
+func TestListAlertsAsyncExportRemovesExpiredSpools(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/alert/csv", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/status", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/download", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testAlertCsv)
+	})
+	query, dir := newAsyncExportTestPlugin(t, m)
+
+	// An expired export and the partial download of an abandoned one
+	old := time.Now().Add(-2 * time.Hour)
+	state, _ := json.Marshal(alertExportState{JobId: "csv-old", SubmittedAt: old, CompletedAt: old})
+	expired := map[string][]byte{
+		"alerts-expired.json":         state,
+		"alerts-expired.csv":          []byte(testAlertCsv),
+		"alerts-abandoned.csv.part":   []byte("Alert ID\n"),
+		"alerts-abandoned-recent.csv": []byte(testAlertCsv),
+	}
+	for name, content := range expired {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if name != "alerts-abandoned-recent.csv" {
+			if err := os.Chtimes(filepath.Join(dir, name), old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if _, err := query(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name := range expired {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if name == "alerts-abandoned-recent.csv" {
+			if err != nil {
+				t.Errorf("expected %s to be kept, got %v", name, err)
+			}
+		} else if !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", name, err)
+		}
+	}
+}
+
+func TestListAlertsAsyncExportNotReusedWithoutTtl(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/alert/csv", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/status", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/download", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testAlertCsv)
+	})
+
+	// Without alert_export_spool_ttl the exports are reused for the query cache TTL, and the query cache is disabled here
+	server := newTestPlugin(t, m, fmt.Sprintf(`
+alert_export_mode      = "async"
+alert_export_spool_dir = %q
+`, t.TempDir()))
+	for i := 0; i < 2; i++ {
+		_, err := testQuery{
+			table:   "prismacloud_alert",
+			columns: []string{"id"},
+			quals:   quals(stringQual("time_range", "=", "epoch")),
+		}.execute(t, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := len(m.received("POST", "/alert/csv")); n != 2 {
+		t.Errorf("expected each query to submit its own export, got %d submits", n)
+	}
+}
+
+func TestListAlertsAsyncExportInvalidTime(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/alert/csv", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/status", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"id": "csv-1", "status": "READY_TO_DOWNLOAD"}
+	})
+	m.handle("GET", "/alert/csv/csv-1/download", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte("Alert ID,Status,Alert Time\nP-1,OPEN,01/05/2024 10:00\n")
+	})
+	query, _ := newAsyncExportTestPlugin(t, m)
+
+	if _, err := query(); err == nil || !strings.Contains(err.Error(), `line 2: invalid Alert Time "01/05/2024 10:00"`) {
+		t.Errorf("expected an invalid alert time error, got %v", err)
+	}
+}
+
+func TestListAlertsAsyncExportUnavailableColumns(t *testing.T) {
+	m := newMockServer(t)
+	server := newTestPlugin(t, m, `alert_export_mode = "async"`)
+
+	_, err := testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id", "history", "risk_detail"},
+		quals:   quals(stringQual("time_range", "=", "epoch")),
+	}.execute(t, server)
+	if err == nil || !strings.Contains(err.Error(), "history, risk_detail can't be read with alert_export_mode 'async'") {
+		t.Errorf("expected an unavailable columns error, got %v", err)
+	}
+	if n := len(m.received("POST", "/alert/csv")); n != 0 {
+		t.Errorf("expected no export to be submitted, got %d", n)
+	}
+}
+
+func TestAlertExportModeInvalid(t *testing.T) {
+	m := newMockServer(t)
+	server := newTestPlugin(t, m, `alert_export_mode = "parallel"`)
+
+	_, err := testQuery{table: "prismacloud_alert", columns: []string{"id"}}.execute(t, server)
+	if err == nil || !strings.Contains(err.Error(), "invalid alert_export_mode") {
+		t.Errorf("expected an invalid alert_export_mode error, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/url"
+
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+)
+
+// Get Cloud Org Accounts
+// https://pan.dev/prisma-cloud/api/cspm/get-cloud-org-accounts/
+func ListCloudOrgAccounts(c *prismacloud.Client, cloudType, id string) ([]model.CloudOrgAccount, error) {
+	c.Log(prismacloud.LogAction, "list of %s: %s", "cloud org accounts", id)
+
+	query := url.Values{
+		"excludeAccountGroupDetails": []string{"true"},
+	}
+
+	var accounts []model.CloudOrgAccount
+	if _, err := c.Communicate("GET", []string{"cloud", cloudType, id, "project"}, query, nil, &accounts); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// Get Cloud Account Config Status
+// https://pan.dev/prisma-cloud/api/cspm/get-account-config-status/
+func GetAccountConfigStatus(c *prismacloud.Client, id string) ([]model.AccountComponentStatus, error) {
+	c.Log(prismacloud.LogAction, "get %s: %s", "account config status", id)
+
+	var components []model.AccountComponentStatus
+	if _, err := c.Communicate("GET", []string{"account", id, "config", "status"}, nil, nil, &components); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
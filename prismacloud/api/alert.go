@@ -1,7 +1,15 @@
 package api
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
 	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/paloaltonetworks/prisma-cloud-go/alert"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
 )
 
@@ -17,3 +25,125 @@ func GetAlertCountOfPolicies(c *prismacloud.Client, req map[string]interface{})
 
 	return &alertCounts, nil
 }
+
+// List Alerts V2 - POST
+// https://pan.dev/prisma-cloud/api/cspm/post-alerts-v-2/
+func ListAlerts(c *prismacloud.Client, req alert.Request) (*model.AlertListResponse, error) {
+	c.Log(prismacloud.LogAction, "list of %s", "alerts")
+
+	// Sanity check the time range
+	if err := req.TimeRange.SetType(); err != nil {
+		return nil, err
+	}
+
+	var alerts model.AlertListResponse
+	if _, err := c.Communicate("POST", []string{"v2", "alert"}, nil, req, &alerts); err != nil {
+		return nil, err
+	}
+
+	return &alerts, nil
+}
+
+// Get Alert Info
+// https://pan.dev/prisma-cloud/api/cspm/get-alert/
+func GetAlert(c *prismacloud.Client, id string) (*model.Alert, error) {
+	c.Log(prismacloud.LogAction, "get %s: %s", "alert", id)
+
+	var a model.Alert
+	if _, err := c.Communicate("GET", []string{"alert", id}, url.Values{"detailed": []string{"true"}}, nil, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// Generate Alerts CSV
+// https://pan.dev/prisma-cloud/api/cspm/generate-alert-csv/
+func SubmitAlertCsvJob(c *prismacloud.Client, req alert.Request) (*model.AlertCsvJob, error) {
+	c.Log(prismacloud.LogAction, "submit %s", "alert csv job")
+
+	// Sanity check the time range
+	if err := req.TimeRange.SetType(); err != nil {
+		return nil, err
+	}
+
+	var job model.AlertCsvJob
+	if _, err := c.Communicate("POST", []string{"alert", "csv"}, nil, req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Get Alert CSV Job Status
+// https://pan.dev/prisma-cloud/api/cspm/get-alert-csv-job-status/
+func GetAlertCsvJobStatus(c *prismacloud.Client, id string) (*model.AlertCsvJob, error) {
+	c.Log(prismacloud.LogAction, "get %s: %s", "alert csv job status", id)
+
+	var job model.AlertCsvJob
+	if _, err := c.Communicate("GET", []string{"alert", "csv", id, "status"}, nil, nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Download Alerts CSV
+// https://pan.dev/prisma-cloud/api/cspm/download-alert-csv/
+// The CSV is streamed to w instead of being read in memory, since it can hold hundreds of thousands of alerts.
+func DownloadAlertCsv(c *prismacloud.Client, id string, w io.Writer) (int64, error) {
+	c.Log(prismacloud.LogAction, "download %s: %s", "alert csv", id)
+
+	path := fmt.Sprintf("%s://%s", c.Protocol, c.Url)
+	if c.Port != 0 {
+		path = fmt.Sprintf("%s:%d", path, c.Port)
+	}
+	path = path + "/" + strings.Join([]string{"alert", "csv", id, "download"}, "/")
+	c.Log(prismacloud.LogPath, "path: %s", path)
+
+	con := &http.Client{
+		Transport: c.Transport,
+		Timeout:   time.Duration(c.Timeout) * time.Second,
+	}
+
+	reauthenticated := false
+	for {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "text/csv")
+		if c.JsonWebToken != "" {
+			req.Header.Set("x-redlock-auth", c.JsonWebToken)
+		}
+
+		resp, err := con.Do(req)
+		if err != nil {
+			return 0, fmt.Errorf("failed to make request: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			n, err := io.Copy(w, resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return n, fmt.Errorf("failed to download alert csv %s: %w", id, err)
+			}
+			return n, nil
+		case http.StatusUnauthorized:
+			resp.Body.Close()
+			// Refresh the JWT once, the same way the client does
+			if !c.DisableReconnect && !reauthenticated {
+				reauthenticated = true
+				if err = c.Authenticate(); err == nil {
+					continue
+				}
+			}
+			return 0, prismacloud.InvalidCredentialsError
+		default:
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			return 0, fmt.Errorf("%d error from %s: %s", resp.StatusCode, path, body)
+		}
+	}
+}
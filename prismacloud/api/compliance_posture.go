@@ -23,3 +23,27 @@ func LisComplianceBreakdownStatistics(c *prismacloud.Client, query url.Values) (
 
 	return &postures, nil
 }
+
+// Get Compliance Trend
+// https://pan.dev/prisma-cloud/api/cspm/get-compliance-posture-trend-v-2/
+// https://pan.dev/prisma-cloud/api/cspm/get-compliance-posture-trend-for-standard-v-2/
+// https://pan.dev/prisma-cloud/api/cspm/get-compliance-posture-trend-for-requirement-v-2/
+// The trend is of all the standards without a standard ID, and of the standard without a requirement ID.
+func ListComplianceTrend(c *prismacloud.Client, standardId, requirementId string, query url.Values) ([]model.ComplianceSummary, error) {
+	c.Log(prismacloud.LogAction, "(get) list of %s", "compliance posture trend")
+
+	path := []string{"v2", "compliance", "posture", "trend"}
+	if standardId != "" {
+		path = append(path, standardId)
+		if requirementId != "" {
+			path = append(path, requirementId)
+		}
+	}
+
+	var trend []model.ComplianceSummary
+	if _, err := c.Communicate("GET", path, query, nil, &trend); err != nil {
+		return nil, err
+	}
+
+	return trend, nil
+}
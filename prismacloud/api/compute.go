@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+)
+
+// ComputeCommunicate handles communication with the Prisma Cloud Compute console.
+// The Compute console is hosted separately from the CSPM API, so the request is sent
+// to computeUrl using the transport, timeout, JWT and retry settings of the given client.
+func ComputeCommunicate(c *prismacloud.Client, computeUrl string, method string, suffix []string, data interface{}, ans interface{}) error {
+	var body []byte
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = b
+	}
+
+	path := strings.TrimRight(computeUrl, "/") + "/" + strings.Join(suffix, "/")
+	c.Log(prismacloud.LogPath, "path: %s", path)
+
+	con := &http.Client{
+		Transport: c.Transport,
+		Timeout:   time.Duration(c.Timeout) * time.Second,
+	}
+
+	retries, maxRetries := c.Retries, c.MaxRetries
+	reauthenticated := false
+	for {
+		req, err := http.NewRequest(method, path, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		if c.JsonWebToken != "" {
+			req.Header.Set("x-redlock-auth", c.JsonWebToken)
+		}
+
+		resp, err := con.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to make request: %w", err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusNoContent, http.StatusCreated:
+			if ans != nil && len(respBody) > 0 {
+				if err = json.Unmarshal(respBody, ans); err != nil {
+					return fmt.Errorf("failed to unmarshal response body: %w", err)
+				}
+			}
+			return nil
+		case http.StatusUnauthorized:
+			// Refresh the JWT once, the same way the CSPM client does
+			if !c.DisableReconnect && !reauthenticated {
+				reauthenticated = true
+				if err = c.Authenticate(); err == nil {
+					continue
+				}
+			}
+			return prismacloud.InvalidCredentialsError
+		case http.StatusTooManyRequests:
+			delay := 1 << retries
+			if delay > 0 && delay <= c.RetryMaxDelay && maxRetries > 0 {
+				time.Sleep(time.Duration(delay) * time.Second)
+				maxRetries--
+				retries++
+				continue
+			}
+			return fmt.Errorf("max_retries or retry_max_delay insufficient")
+		default:
+			return fmt.Errorf("%d error from %s: %s", resp.StatusCode, path, respBody)
+		}
+	}
+}
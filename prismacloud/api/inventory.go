@@ -1,11 +1,6 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 
 	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
@@ -38,149 +33,56 @@ func ListInventoryAsset(c *prismacloud.Client, query url.Values) (*model.Grouped
 	return &assets, nil
 }
 
-func GetInventoryWorkloads(authToken string) (*model.InventoryWorkload, error) {
-	// Define the URL and headers
-	url := "https://asia-northeast1.cloud.twistlock.com/anz-3001938/api/v1/bff/assets/summary"
-	contentType := "application/json"
-	accept := "application/json"
-
-	// Create a new request
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Accept", accept)
-	req.Header.Set("x-redlock-auth", authToken)
-
-	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+// Get Workload Summary
+// This API is not documented.
+// It was obtained by inspecting the Prisma Cloud Compute console.
+func GetInventoryWorkloads(c *prismacloud.Client, computeUrl string) (*model.InventoryWorkload, error) {
+	c.Log(prismacloud.LogAction, "get %s", "inventory workloads")
 
-	// Unmarshal the response body into the ContainerImages struct
 	var workload model.InventoryWorkload
-	err = json.Unmarshal(body, &workload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	if err := ComputeCommunicate(c, computeUrl, "GET", []string{"api", "v1", "bff", "assets", "summary"}, nil, &workload); err != nil {
+		return nil, err
 	}
 
 	return &workload, nil
 }
 
-func GetInventoryWorkloadContainerImages(authToken string, nextPageToken string) (*model.WorkloadContainerImagesResponse, error) {
-	// Define the URL and headers
-	url := "https://asia-northeast1.cloud.twistlock.com/anz-3001938/api/v1/bff/images/collated"
-	contentType := "application/json"
-	accept := "application/json"
+// List Workload Container Images
+// This API is not documented.
+// It was obtained by inspecting the Prisma Cloud Compute console.
+func GetInventoryWorkloadContainerImages(c *prismacloud.Client, computeUrl string, nextPageToken string) (*model.WorkloadContainerImagesResponse, error) {
+	c.Log(prismacloud.LogAction, "list of %s", "inventory workload container images")
 
-	// Create the payload
-	payload := map[string]interface{}{
+	req := map[string]interface{}{
 		"stage":         "all",
 		"sort":          "vulnerabilities",
 		"limit":         30,
 		"nextPageToken": nextPageToken,
 	}
 
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal payload: %w", err)
-	}
-
-	// Create a new request with the payload
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Accept", accept)
-	req.Header.Set("x-redlock-auth", authToken)
-
-	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Unmarshal the response body into the ContainerImages struct
 	var cImages model.WorkloadContainerImagesResponse
-	err = json.Unmarshal(body, &cImages)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	if err := ComputeCommunicate(c, computeUrl, "POST", []string{"api", "v1", "bff", "images", "collated"}, req, &cImages); err != nil {
+		return nil, err
 	}
 
 	return &cImages, nil
 }
 
-func GetInventoryWorkloadHosts(authToken string, nextPageToken string) (*model.WorkloadContainerHostResponse, error) {
-	// Define the URL and headers
-	url := "https://asia-northeast1.cloud.twistlock.com/anz-3001938/api/v1/bff/hosts"
-	contentType := "application/json"
-	accept := "application/json"
+// List Workload Hosts
+// This API is not documented.
+// It was obtained by inspecting the Prisma Cloud Compute console.
+func GetInventoryWorkloadHosts(c *prismacloud.Client, computeUrl string, nextPageToken string) (*model.WorkloadContainerHostResponse, error) {
+	c.Log(prismacloud.LogAction, "list of %s", "inventory workload hosts")
 
-	// Create the payload
-	payload := map[string]interface{}{
+	req := map[string]interface{}{
 		"sort":          "vulnerabilities",
 		"limit":         30,
 		"nextPageToken": nextPageToken,
 	}
 
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal payload: %w", err)
-	}
-
-	// Create a new request with the payload
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Accept", accept)
-	req.Header.Set("x-redlock-auth", authToken)
-
-	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Unmarshal the response body into the ContainerImages struct
 	var hosts model.WorkloadContainerHostResponse
-	err = json.Unmarshal(body, &hosts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	if err := ComputeCommunicate(c, computeUrl, "POST", []string{"api", "v1", "bff", "hosts"}, req, &hosts); err != nil {
+		return nil, err
 	}
 
 	return &hosts, nil
@@ -0,0 +1,19 @@
+package api
+
+import (
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+)
+
+// Get Tenant Meta Info
+// https://pan.dev/prisma-cloud/api/cspm/meta-info/
+func GetMetaInfo(c *prismacloud.Client) (*model.MetaInfo, error) {
+	c.Log(prismacloud.LogAction, "get %s", "tenant meta info")
+
+	var info *model.MetaInfo
+	if _, err := c.Communicate("GET", []string{"meta_info"}, nil, nil, &info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+)
+
+// Get Resource Information
+// https://pan.dev/prisma-cloud/api/cspm/get-resource/
+func GetResourceByRrn(c *prismacloud.Client, rrn string) (*model.ResourceInfo, error) {
+	c.Log(prismacloud.LogAction, "get %s: %s", "resource", rrn)
+
+	req := map[string]interface{}{
+		"rrn": rrn,
+	}
+
+	var resource model.ResourceInfo
+	if _, err := c.Communicate("POST", []string{"resource"}, nil, req, &resource); err != nil {
+		return nil, err
+	}
+
+	return &resource, nil
+}
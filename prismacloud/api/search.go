@@ -0,0 +1,58 @@
+package api
+
+import (
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+)
+
+// Perform Config Search
+// https://pan.dev/prisma-cloud/api/cspm/search-config/
+func ConfigSearch(c *prismacloud.Client, req map[string]interface{}) (*model.ConfigSearchResponse, error) {
+	c.Log(prismacloud.LogAction, "perform %s", "config search")
+
+	var resp model.ConfigSearchResponse
+	if _, err := c.Communicate("POST", []string{"search", "config"}, nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Config Search Page
+// https://pan.dev/prisma-cloud/api/cspm/search-config-page/
+func ConfigSearchPage(c *prismacloud.Client, req map[string]interface{}) (*model.ConfigSearchData, error) {
+	c.Log(prismacloud.LogAction, "get %s", "config search page")
+
+	var page model.ConfigSearchData
+	if _, err := c.Communicate("POST", []string{"search", "config", "page"}, nil, req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// Perform Event Search
+// https://pan.dev/prisma-cloud/api/cspm/search-event/
+func EventSearch(c *prismacloud.Client, req map[string]interface{}) (*model.EventSearchResponse, error) {
+	c.Log(prismacloud.LogAction, "perform %s", "event search")
+
+	var resp model.EventSearchResponse
+	if _, err := c.Communicate("POST", []string{"search", "event"}, nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Perform Network Search
+// https://pan.dev/prisma-cloud/api/cspm/search/
+func NetworkSearch(c *prismacloud.Client, req map[string]interface{}) (*model.NetworkSearchResponse, error) {
+	c.Log(prismacloud.LogAction, "perform %s", "network search")
+
+	var resp model.NetworkSearchResponse
+	if _, err := c.Communicate("POST", []string{"search"}, nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
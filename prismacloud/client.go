@@ -2,29 +2,50 @@ package prismacloud
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
-func connect(_ context.Context, d *plugin.QueryData) (*prismacloud.Client, error) {
+// Refresh the JWT of a cached client when it is this close to expiring
+const jwtRefreshWindow = 2 * time.Minute
+
+// cachedClient holds an authenticated client along with the expiry of its JWT
+type cachedClient struct {
+	client      *prismacloud.Client
+	expiresAt   time.Time
+	staticToken bool
+	mu          sync.Mutex
+}
+
+func connect(ctx context.Context, d *plugin.QueryData) (*prismacloud.Client, error) {
+	prismacloudConfig, err := getResolvedConfig(d)
+	if err != nil {
+		return nil, err
+	}
+
 	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "prismacloud"
+	// The key is per connection and credentials, so connections to different tenants never share a client
+	cacheKey := clientCacheKey(d.Connection, prismacloudConfig)
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
-		return cachedData.(*prismacloud.Client), nil
+		return cachedData.(*cachedClient).refresh(ctx)
 	}
 
 	c := prismacloud.Client{}
 
-	prismacloudConfig := GetConfig(d.Connection)
-
 	// Return error if the minimum credential is not provided
 	if (prismacloudConfig.Username == nil || prismacloudConfig.Password == nil) && (prismacloudConfig.Token == nil) {
-		return nil, fmt.Errorf("'username' and 'password' or 'token' must be set in the connection configuration. Edit your connection configuration file and then restart Steampipe")
+		return nil, fmt.Errorf("'username' and 'password' or 'token' must be set in the connection configuration, the PRISMACLOUD_* environment variables or the 'config_file'. Edit your connection configuration file and then restart Steampipe")
 	}
 
 	// Logging
@@ -144,10 +165,136 @@ func connect(_ context.Context, d *plugin.QueryData) (*prismacloud.Client, error
 		c.RetryMaxDelay = 5000 // Default to 5000 milliseconds
 	}
 
-	err := c.Initialize("")
+	// A static token can only be extended while it is still valid
+	staticToken := c.Username == "" || c.Password == ""
+	if staticToken {
+		if expiresAt := jwtExpiry(c.JsonWebToken); !expiresAt.IsZero() && time.Now().After(expiresAt) {
+			return nil, tokenExpiredError(expiresAt)
+		}
+	}
+
+	err = c.Initialize("")
 	if err != nil {
 		return nil, fmt.Errorf("error in initialize client: %v+", err)
 	}
 
+	cached := &cachedClient{
+		client:      &c,
+		expiresAt:   jwtExpiry(c.JsonWebToken),
+		staticToken: staticToken,
+	}
+	d.ConnectionManager.Cache.Set(cacheKey, cached)
+
 	return &c, nil
-}
\ No newline at end of file
+}
+
+// refresh returns the cached client, re-authenticating it first when its JWT is about to expire.
+// With username and password a new login is performed, otherwise the static token is extended.
+func (cc *cachedClient) refresh(ctx context.Context) (*prismacloud.Client, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.expiresAt.IsZero() || time.Until(cc.expiresAt) > jwtRefreshWindow {
+		return cc.client, nil
+	}
+
+	if cc.staticToken && time.Now().After(cc.expiresAt) {
+		return nil, tokenExpiredError(cc.expiresAt)
+	}
+
+	// Authenticate a copy of the client, since hydrates still running may be using the current one
+	c := *cc.client
+	if err := c.Authenticate(); err != nil {
+		plugin.Logger(ctx).Error("connect.refresh", "auth_error", err)
+		if cc.staticToken {
+			return nil, fmt.Errorf("unable to extend the configured 'token' which expires at %s: %v", cc.expiresAt.Format(time.RFC3339), err)
+		}
+		return nil, fmt.Errorf("error in refreshing client authentication: %v", err)
+	}
+	cc.client = &c
+	cc.expiresAt = jwtExpiry(c.JsonWebToken)
+
+	return cc.client, nil
+}
+
+func tokenExpiredError(expiresAt time.Time) error {
+	return fmt.Errorf("the 'token' in the connection configuration expired at %s. Set a new token, or use 'username' and 'password' so the plugin can log in again", expiresAt.Format(time.RFC3339))
+}
+
+// jwtExpiry returns the expiry time from the 'exp' claim of a JWT, or the zero time if it can't be read
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
+
+// clientCacheKey builds a cache key from the connection name and a fingerprint of its credentials
+func clientCacheKey(connection *plugin.Connection, config prismaCloudConfig) string {
+	name := ""
+	if connection != nil {
+		name = connection.Name
+	}
+
+	h := sha256.New()
+	for _, v := range []*string{config.Url, config.CustomerName, config.Username, config.Password, config.Token} {
+		if v != nil {
+			h.Write([]byte(*v))
+		}
+		h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("prismacloud-%s-%x", name, h.Sum(nil)[:8])
+}
+
+// getComputeUrl returns the base URL of the Prisma Cloud Compute console for the connection.
+// The 'compute_url' connection option takes precedence, otherwise the URL is discovered
+// from the meta info of the CSPM tenant.
+func getComputeUrl(ctx context.Context, d *plugin.QueryData, c *prismacloud.Client) (string, error) {
+	cacheKey := "prismacloud_compute_url"
+	if d.Connection != nil {
+		cacheKey = fmt.Sprintf("prismacloud_compute_url-%s", d.Connection.Name)
+	}
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(string), nil
+	}
+
+	computeUrl := ""
+	prismacloudConfig := GetConfig(d.Connection)
+	if prismacloudConfig.ComputeUrl != nil {
+		computeUrl = *prismacloudConfig.ComputeUrl
+	} else {
+		info, err := api.GetMetaInfo(c)
+		if err != nil {
+			plugin.Logger(ctx).Error("getComputeUrl", "api_error", err)
+			return "", fmt.Errorf("unable to discover the Prisma Cloud Compute URL, set 'compute_url' in the connection configuration: %v", err)
+		}
+		computeUrl = info.TwistlockUrl
+	}
+
+	computeUrl = strings.TrimRight(computeUrl, "/")
+	if computeUrl == "" {
+		return "", fmt.Errorf("prismacloud Compute URL is not set")
+	}
+	if !strings.HasPrefix(computeUrl, "http://") && !strings.HasPrefix(computeUrl, "https://") {
+		computeUrl = c.Protocol + "://" + computeUrl
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, computeUrl)
+
+	return computeUrl, nil
+}
@@ -0,0 +1,89 @@
+package prismacloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListAPIErrorIsReturned(t *testing.T) {
+	m := newMockServer(t)
+	m.handleError("GET", "/v2/policy", http.StatusBadRequest, "invalid_parameter")
+	server := newTestPlugin(t, m, "")
+
+	_, err := testQuery{table: "prismacloud_policy", columns: []string{"policy_id"}}.execute(t, server)
+	if err == nil || !strings.Contains(err.Error(), "invalid_parameter") {
+		t.Errorf("expected the API error to be returned, got %v", err)
+	}
+}
+
+func TestGetNotFoundReturnsNoRows(t *testing.T) {
+	m := newMockServer(t)
+	m.handleError("GET", "/policy/missing", http.StatusBadRequest, "not_found")
+	server := newTestPlugin(t, m, "")
+
+	rows, err := testQuery{
+		table:   "prismacloud_policy",
+		columns: []string{"policy_id"},
+		quals:   quals(stringQual("policy_id", "=", "missing")),
+	}.execute(t, server)
+	if err != nil {
+		t.Fatalf("expected not found to be ignored, got %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestReloginOnUnauthorized(t *testing.T) {
+	m := newMockServer(t)
+	accounts := loadFixture(t, "cloud_accounts.json")
+	calls := 0
+	m.handle("GET", "/cloud", func(r mockRequest) (int, interface{}) {
+		calls++
+		if calls == 1 {
+			return http.StatusUnauthorized, mockError{"invalid_credentials"}
+		}
+		return http.StatusOK, accounts
+	})
+
+	rows := testQuery{table: "prismacloud_account", columns: []string{"account_id"}}.run(t, m)
+	if len(rows) != 2 {
+		t.Errorf("expected 2 rows, got %d", len(rows))
+	}
+	if got := len(m.received("POST", "/login")); got != 2 {
+		t.Errorf("expected a second login after the 401, got %d logins", got)
+	}
+}
+
+func TestMissingCredentials(t *testing.T) {
+	for _, name := range []string{"PRISMACLOUD_USERNAME", "PRISMACLOUD_PASSWORD", "PRISMACLOUD_TOKEN"} {
+		t.Setenv(name, "")
+	}
+	m := newMockServer(t)
+	server := newTestPluginWithConfig(t, mockConnectionConfig(m))
+
+	_, err := testQuery{table: "prismacloud_account", columns: []string{"account_id"}}.execute(t, server)
+	if err == nil || !strings.Contains(err.Error(), "'username' and 'password' or 'token' must be set") {
+		t.Errorf("expected a missing credentials error, got %v", err)
+	}
+	if got := len(m.received("POST", "/login")); got != 0 {
+		t.Errorf("expected no login, got %d", got)
+	}
+}
+
+func TestExpiredStaticToken(t *testing.T) {
+	for _, name := range []string{"PRISMACLOUD_USERNAME", "PRISMACLOUD_PASSWORD"} {
+		t.Setenv(name, "")
+	}
+	m := newMockServer(t)
+	token := mockToken(time.Now().Add(-time.Hour))
+	server := newTestPluginWithConfig(t, mockConnectionConfig(m)+fmt.Sprintf("token = %q\n", token))
+
+	_, err := testQuery{table: "prismacloud_account", columns: []string{"account_id"}}.execute(t, server)
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected an expired token error, got %v", err)
+	}
+}
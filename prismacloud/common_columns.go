@@ -1,6 +1,11 @@
 package prismacloud
 
 import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -17,3 +22,84 @@ func commonColumns(cols []*plugin.Column) []*plugin.Column {
 		},
 	}, cols...)
 }
+
+//// TRANSFORM FUNCTIONS
+
+// Column transforms for the value types which need converting, so every table exposes them with the same column type:
+// - Unix millisecond times and time strings as TIMESTAMP, null when unset
+// - IP addresses as INET and CIDR blocks as CIDR, null when the value isn't one
+
+// fromEpochMsField returns the transform of a TIMESTAMP column from a field holding Unix milliseconds
+func fromEpochMsField(field string) *transform.ColumnTransforms {
+	return transform.FromField(field).Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp)
+}
+
+// fromTimeStringField returns the transform of a TIMESTAMP column from a field holding a formatted time
+func fromTimeStringField(field string) *transform.ColumnTransforms {
+	return transform.FromField(field).Transform(timeStringToTimestamp)
+}
+
+// fromIpField returns the transform of an INET column from a field holding an IP address
+func fromIpField(field string) *transform.ColumnTransforms {
+	return transform.FromField(field).Transform(ipAddress)
+}
+
+// fromCidrField returns the transform of a CIDR column from a field holding a CIDR block
+func fromCidrField(field string) *transform.ColumnTransforms {
+	return transform.FromField(field).Transform(cidrBlock)
+}
+
+// Layouts of the formatted times returned by the API
+var timeStringLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func timeStringToTimestamp(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	value, ok := d.Value.(string)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	for _, layout := range timeStringLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	// Placeholders such as "NA" are returned for resources which were never accessed
+	return nil, nil
+}
+
+func ipAddress(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	value, ok := d.Value.(string)
+	if !ok {
+		return nil, nil
+	}
+	value = strings.TrimSpace(value)
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String(), nil
+	}
+	// Network nodes such as the internet have no address
+	return nil, nil
+}
+
+func cidrBlock(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	value, ok := d.Value.(string)
+	if !ok {
+		return nil, nil
+	}
+	value = strings.TrimSpace(value)
+	// A single address is a block of one
+	if ip := net.ParseIP(value); ip != nil {
+		if ip.To4() != nil {
+			return ip.String() + "/32", nil
+		}
+		return ip.String() + "/128", nil
+	}
+	// CIDR columns reject host bits, so the block is returned as its network
+	if _, block, err := net.ParseCIDR(value); err == nil {
+		return block.String(), nil
+	}
+	return nil, nil
+}
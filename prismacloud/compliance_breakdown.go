@@ -0,0 +1,174 @@
+package prismacloud
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"sync"
+
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Maximum number of compliance posture calls made at the same time by a query fanning out per account or cloud type
+const complianceBreakdownMaxConcurrency = 5
+
+// The account or cloud type a compliance posture was fetched for.
+// The account fields are empty for postures spanning several accounts.
+type complianceBreakdownScope struct {
+	AccountName string
+	AccountId   string
+	CloudType   string
+}
+
+// The compliance posture API returns nothing without one of these parameters
+var complianceBreakdownScopeParameters = []string{
+	"cloud.account",
+	"cloud.type",
+	"account.group",
+	"cloud.region",
+	"policy.complianceStandard",
+	"policy.complianceRequirement",
+	"policy.complianceSection",
+}
+
+// listComplianceBreakdowns fetches the compliance postures matching the quals, calling fn with each of them.
+//
+// The postures are fetched with a single call when the quals give the API a parameter to work with
+// and the query doesn't need the account columns.
+// Otherwise they are fetched per account when the query needs the account columns, or else per cloud type,
+// with at most complianceBreakdownMaxConcurrency calls at the same time.
+func listComplianceBreakdowns(ctx context.Context, d *plugin.QueryData, fn func(complianceBreakdownScope, *model.ComplianceData) bool) error {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	query := buildComplianceBreakdownStatisticQueryParameter(ctx, d, url.Values{})
+
+	accountGranularity := d.EqualsQualString("account_name") != ""
+	for _, column := range d.QueryContext.Columns {
+		if column == "account_name" || column == "account_id" {
+			accountGranularity = true
+		}
+	}
+
+	if !accountGranularity && hasComplianceBreakdownScope(query) {
+		if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+			return err
+		}
+
+		postures, err := api.LisComplianceBreakdownStatistics(conn, query)
+		if err != nil {
+			return err
+		}
+		fn(complianceBreakdownScope{CloudType: d.EqualsQualString("cloud_type")}, postures)
+		return nil
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return err
+	}
+
+	accounts, err := account.List(conn)
+	if err != nil {
+		return err
+	}
+
+	var scopes []complianceBreakdownScope
+	cloudTypes := map[string]bool{}
+	for _, a := range accounts {
+		if d.EqualsQualString("account_name") != "" && d.EqualsQualString("account_name") != a.Name {
+			continue
+		}
+		if d.EqualsQualString("cloud_type") != "" && d.EqualsQualString("cloud_type") != a.CloudType {
+			continue
+		}
+
+		if accountGranularity {
+			scopes = append(scopes, complianceBreakdownScope{AccountName: a.Name, AccountId: a.AccountId, CloudType: a.CloudType})
+		} else if !cloudTypes[a.CloudType] {
+			cloudTypes[a.CloudType] = true
+			scopes = append(scopes, complianceBreakdownScope{CloudType: a.CloudType})
+		}
+	}
+	sort.SliceStable(scopes, func(i, j int) bool {
+		return scopes[i].CloudType < scopes[j].CloudType
+	})
+
+	return fanOutComplianceBreakdowns(ctx, d, conn, scopes, query, fn)
+}
+
+// Fetch the compliance posture of each scope, with at most complianceBreakdownMaxConcurrency calls at the same time
+func fanOutComplianceBreakdowns(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, scopes []complianceBreakdownScope, query url.Values, fn func(complianceBreakdownScope, *model.ComplianceData) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	semaphore := make(chan struct{}, complianceBreakdownMaxConcurrency)
+
+	for _, scope := range scopes {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(scope complianceBreakdownScope) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			scopeQuery := url.Values{}
+			for k, v := range query {
+				scopeQuery[k] = v
+			}
+			if scope.AccountName != "" {
+				scopeQuery.Set("cloud.account", scope.AccountName)
+			} else {
+				scopeQuery.Set("cloud.type", scope.CloudType)
+			}
+
+			err := waitForRateLimit(ctx, d, serviceCompliance)
+			var postures *model.ComplianceData
+			if err == nil {
+				postures, err = api.LisComplianceBreakdownStatistics(conn, scopeQuery)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				firstErr = err
+				cancel()
+				return
+			}
+			if !fn(scope, postures) {
+				cancel()
+			}
+		}(scope)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+func hasComplianceBreakdownScope(query url.Values) bool {
+	for _, param := range complianceBreakdownScopeParameters {
+		if query.Get(param) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -1,24 +1,56 @@
 package prismacloud
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
 type prismaCloudConfig struct {
-	Url                     *string         `hcl:"url"`
-	Username                *string         `hcl:"username,optional"`
-	Password                *string         `hcl:"password,optional"`
-	CustomerName            *string         `hcl:"customer_name,optional"`
-	Protocol                *string         `hcl:"protocol,optional"`
-	Port                    *int32          `hcl:"port,optional"`
-	Timeout                 *int32          `hcl:"timeout,optional"`
-	SkipSslCertVerification *bool           `hcl:"skip_ssl_cert_verification,optional"`
-	Logging                 map[string]bool `hcl:"logging,optional"`
-	DisableReconnect        *bool           `hcl:"disable_reconnect,optional"`
-	MaxRetries              *int            `hcl:"max_retries,optional"`
-	RetryMaxDelay           *int            `hcl:"retry_max_delay,optional"`
-	Retries                 *int            `hcl:"retries,optional"`
-	Token                   *string         `hcl:"token,optional"`
+	Url                     *string            `hcl:"url,optional"`
+	Username                *string            `hcl:"username,optional"`
+	Password                *string            `hcl:"password,optional"`
+	CustomerName            *string            `hcl:"customer_name,optional"`
+	Protocol                *string            `hcl:"protocol,optional"`
+	Port                    *int32             `hcl:"port,optional"`
+	Timeout                 *int32             `hcl:"timeout,optional"`
+	SkipSslCertVerification *bool              `hcl:"skip_ssl_cert_verification,optional"`
+	Logging                 map[string]bool    `hcl:"logging,optional"`
+	DisableReconnect        *bool              `hcl:"disable_reconnect,optional"`
+	MaxRetries              *int               `hcl:"max_retries,optional"`
+	RetryMaxDelay           *int               `hcl:"retry_max_delay,optional"`
+	Retries                 *int               `hcl:"retries,optional"`
+	Token                   *string            `hcl:"token,optional"`
+	ComputeUrl              *string            `hcl:"compute_url,optional"`
+	ConfigFile              *string            `hcl:"config_file,optional"`
+	RateLimit               map[string]float64 `hcl:"rate_limit,optional"`
+	RateLimitBurst          map[string]int     `hcl:"rate_limit_burst,optional"`
+	AlertExportMode         *string            `hcl:"alert_export_mode,optional"`
+	AlertExportSpoolDir     *string            `hcl:"alert_export_spool_dir,optional"`
+	AlertExportSpoolTtl     *int               `hcl:"alert_export_spool_ttl,optional"`
+}
+
+// prismaCloudConfigFile is the JSON credentials file format used by the Prisma Cloud Terraform provider
+// https://registry.terraform.io/providers/PaloAltoNetworks/prismacloud/latest/docs#json-config-file
+type prismaCloudConfigFile struct {
+	Url                     *string         `json:"url"`
+	Username                *string         `json:"username"`
+	Password                *string         `json:"password"`
+	CustomerName            *string         `json:"customer_name"`
+	Protocol                *string         `json:"protocol"`
+	Port                    *int32          `json:"port"`
+	Timeout                 *int32          `json:"timeout"`
+	SkipSslCertVerification *bool           `json:"skip_ssl_cert_verification"`
+	Logging                 map[string]bool `json:"logging"`
+	DisableReconnect        *bool           `json:"disable_reconnect"`
+	MaxRetries              *int            `json:"max_retries"`
+	RetryMaxDelay           *int            `json:"retry_max_delay"`
+	Retries                 *int            `json:"retries"`
+	Token                   *string         `json:"json_web_token"`
 }
 
 func ConfigInstance() interface{} {
@@ -33,3 +65,151 @@ func GetConfig(connection *plugin.Connection) prismaCloudConfig {
 	config, _ := connection.Config.(prismaCloudConfig)
 	return config
 }
+
+// getResolvedConfig returns the connection configuration resolved by resolveConfig.
+// The result is cached with the connection, so the credentials file is only read once.
+func getResolvedConfig(d *plugin.QueryData) (prismaCloudConfig, error) {
+	cacheKey := "prismacloud_config"
+	if d.Connection != nil {
+		cacheKey = fmt.Sprintf("prismacloud_config-%s", d.Connection.Name)
+	}
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(prismaCloudConfig), nil
+	}
+
+	config, err := resolveConfig(GetConfig(d.Connection))
+	if err != nil {
+		return config, err
+	}
+	// No expiry, the connection cache is cleared when the connection configuration changes
+	d.ConnectionManager.Cache.SetWithTTL(cacheKey, config, 0)
+
+	return config, nil
+}
+
+// prismaCloudCredentials are the settings used to log in, which are always taken from the same source
+type prismaCloudCredentials struct {
+	Username     *string
+	Password     *string
+	Token        *string
+	CustomerName *string
+}
+
+// any returns true if the source supplies a username, password or token
+func (c prismaCloudCredentials) any() bool {
+	return c.Username != nil || c.Password != nil || c.Token != nil
+}
+
+// resolveConfig fills the settings missing from the connection configuration.
+// Precedence is the connection configuration, then the PRISMACLOUD_* environment
+// variables, then the JSON credentials file set in 'config_file'.
+// Credentials are resolved as one set: the first source with a username, password or token
+// supplies all of them, so credentials from different sources are never combined.
+func resolveConfig(config prismaCloudConfig) (prismaCloudConfig, error) {
+	credentials := prismaCloudCredentials{
+		Username:     config.Username,
+		Password:     config.Password,
+		Token:        config.Token,
+		CustomerName: config.CustomerName,
+	}
+
+	env := prismaCloudCredentials{
+		Username:     lookupEnv("PRISMACLOUD_USERNAME"),
+		Password:     lookupEnv("PRISMACLOUD_PASSWORD"),
+		Token:        lookupEnv("PRISMACLOUD_TOKEN"),
+		CustomerName: lookupEnv("PRISMACLOUD_CUSTOMER_NAME"),
+	}
+	if !credentials.any() && env.any() {
+		credentials = env
+	}
+	if config.Url == nil {
+		config.Url = lookupEnv("PRISMACLOUD_URL")
+	}
+
+	if config.ConfigFile != nil && *config.ConfigFile != "" {
+		file, err := readConfigFile(*config.ConfigFile)
+		if err != nil {
+			return config, err
+		}
+
+		fileCredentials := prismaCloudCredentials{
+			Username:     file.Username,
+			Password:     file.Password,
+			Token:        file.Token,
+			CustomerName: file.CustomerName,
+		}
+		if !credentials.any() && fileCredentials.any() {
+			credentials = fileCredentials
+		}
+
+		if config.Url == nil {
+			config.Url = file.Url
+		}
+		if config.Protocol == nil {
+			config.Protocol = file.Protocol
+		}
+		if config.Port == nil {
+			config.Port = file.Port
+		}
+		if config.Timeout == nil {
+			config.Timeout = file.Timeout
+		}
+		if config.SkipSslCertVerification == nil {
+			config.SkipSslCertVerification = file.SkipSslCertVerification
+		}
+		if len(config.Logging) == 0 {
+			config.Logging = file.Logging
+		}
+		if config.DisableReconnect == nil {
+			config.DisableReconnect = file.DisableReconnect
+		}
+		if config.MaxRetries == nil {
+			config.MaxRetries = file.MaxRetries
+		}
+		if config.RetryMaxDelay == nil {
+			config.RetryMaxDelay = file.RetryMaxDelay
+		}
+		if config.Retries == nil {
+			config.Retries = file.Retries
+		}
+	}
+
+	config.Username = credentials.Username
+	config.Password = credentials.Password
+	config.Token = credentials.Token
+	config.CustomerName = credentials.CustomerName
+
+	return config, nil
+}
+
+// readConfigFile reads the JSON credentials file, expanding a leading ~/ to the home directory
+func readConfigFile(path string) (prismaCloudConfigFile, error) {
+	var file prismaCloudConfigFile
+
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return file, fmt.Errorf("failed to resolve home directory for config_file: %v", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("failed to read config_file %s: %v", path, err)
+	}
+
+	if err := json.Unmarshal(b, &file); err != nil {
+		return file, fmt.Errorf("failed to parse config_file %s: %v", path, err)
+	}
+
+	return file, nil
+}
+
+// lookupEnv returns the value of a non-empty environment variable, or nil
+func lookupEnv(name string) *string {
+	if val, ok := os.LookupEnv(name); ok && val != "" {
+		return &val
+	}
+	return nil
+}
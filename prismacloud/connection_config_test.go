@@ -0,0 +1,111 @@
+package prismacloud
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "credentials.json")
+	err := os.WriteFile(file, []byte(`{
+  "url": "api.file.prismacloud.io",
+  "username": "file-user",
+  "password": "file-password",
+  "customer_name": "file-customer",
+  "protocol": "http"
+}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func setCredentialsEnv(t *testing.T, values map[string]string) {
+	t.Helper()
+
+	for _, name := range []string{"PRISMACLOUD_URL", "PRISMACLOUD_USERNAME", "PRISMACLOUD_PASSWORD", "PRISMACLOUD_TOKEN", "PRISMACLOUD_CUSTOMER_NAME"} {
+		t.Setenv(name, values[name])
+	}
+}
+
+func TestResolveConfigPrecedence(t *testing.T) {
+	file := writeConfigFile(t)
+
+	for name, tc := range map[string]struct {
+		config prismaCloudConfig
+		env    map[string]string
+		want   map[string]*string
+	}{
+		"credentials from the connection config": {
+			config: prismaCloudConfig{Token: strPtr("config-token"), ConfigFile: &file},
+			env:    map[string]string{"PRISMACLOUD_USERNAME": "env-user", "PRISMACLOUD_PASSWORD": "env-password"},
+			want: map[string]*string{
+				"token":    strPtr("config-token"),
+				"username": nil,
+				"password": nil,
+				"url":      strPtr("api.file.prismacloud.io"),
+			},
+		},
+		"credentials from the environment": {
+			config: prismaCloudConfig{Url: strPtr("api.config.prismacloud.io"), ConfigFile: &file},
+			env:    map[string]string{"PRISMACLOUD_USERNAME": "env-user", "PRISMACLOUD_PASSWORD": "env-password", "PRISMACLOUD_URL": "api.env.prismacloud.io"},
+			want: map[string]*string{
+				"username":      strPtr("env-user"),
+				"password":      strPtr("env-password"),
+				"customer_name": nil,
+				"url":           strPtr("api.config.prismacloud.io"),
+			},
+		},
+		"credentials from the config file": {
+			config: prismaCloudConfig{ConfigFile: &file},
+			env:    map[string]string{"PRISMACLOUD_CUSTOMER_NAME": "env-customer"},
+			want: map[string]*string{
+				"username":      strPtr("file-user"),
+				"password":      strPtr("file-password"),
+				"customer_name": strPtr("file-customer"),
+				"url":           strPtr("api.file.prismacloud.io"),
+				"protocol":      strPtr("http"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setCredentialsEnv(t, tc.env)
+
+			config, err := resolveConfig(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]*string{
+				"username":      config.Username,
+				"password":      config.Password,
+				"token":         config.Token,
+				"customer_name": config.CustomerName,
+				"url":           config.Url,
+				"protocol":      config.Protocol,
+			}
+			for field, want := range tc.want {
+				switch {
+				case want == nil && got[field] != nil:
+					t.Errorf("%s: expected no value, got %q", field, *got[field])
+				case want != nil && (got[field] == nil || *got[field] != *want):
+					t.Errorf("%s: expected %q, got %v", field, *want, got[field])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveConfigMissingFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "missing.json")
+	if _, err := resolveConfig(prismaCloudConfig{ConfigFile: &file}); err == nil {
+		t.Error("expected an error for a missing config_file")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,27 @@
+package model
+
+type CloudOrgAccount struct {
+	AccountId             string   `json:"accountId"`
+	Name                  string   `json:"name"`
+	CloudType             string   `json:"cloudType"`
+	AccountType           string   `json:"accountType"`
+	Enabled               bool     `json:"enabled"`
+	Status                string   `json:"status"`
+	DeploymentType        string   `json:"deploymentType"`
+	ProtectionMode        string   `json:"protectionMode"`
+	NumberOfChildAccounts int      `json:"numberOfChildAccounts"`
+	AddedOn               int64    `json:"addedOn"`
+	LastModifiedTs        int64    `json:"lastModifiedTs"`
+	LastModifiedBy        string   `json:"lastModifiedBy"`
+	GroupIds              []string `json:"groupIds"`
+}
+
+type AccountComponentStatus struct {
+	Name           string                   `json:"name"`
+	Status         string                   `json:"status"`
+	Message        interface{}              `json:"message"`
+	Remediation    interface{}              `json:"remediation"`
+	LastIngestedTs int64                    `json:"lastIngestedTs"`
+	LastUpdatedTs  int64                    `json:"lastUpdatedTs"`
+	SubComponents  []AccountComponentStatus `json:"subComponents"`
+}
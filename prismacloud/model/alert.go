@@ -1,5 +1,7 @@
 package model
 
+import "github.com/paloaltonetworks/prisma-cloud-go/alert"
+
 type ComplianceMetadata struct {
 	StandardName           string `json:"standardName"`
 	StandardDescription    string `json:"standardDescription"`
@@ -19,19 +21,19 @@ type ComplianceMetadata struct {
 }
 
 type Policy struct {
-	AlertCount            int                `json:"alertCount"`
-	PolicyId              string             `json:"policyId"`
-	PolicyName            string             `json:"policyName"`
-	PolicyType            string             `json:"policyType"`
-	Severity              string             `json:"severity"`
-	PolicyLabels          []string           `json:"policyLabels"`
-	ComplianceMetadata    []ComplianceMetadata `json:"complianceMetadata"`
-	ResourceType          string             `json:"resourceType"`
-	Remediable            bool               `json:"remediable"`
-	CloudType             string             `json:"cloudType"`
-	MittreAttacks         []string           `json:"mittreAttacks"`
-	FindingTypes          []string           `json:"findingTypes"`
-	RestrictAlertDismissal bool              `json:"restrictAlertDismissal"`
+	AlertCount             int                  `json:"alertCount"`
+	PolicyId               string               `json:"policyId"`
+	PolicyName             string               `json:"policyName"`
+	PolicyType             string               `json:"policyType"`
+	Severity               string               `json:"severity"`
+	PolicyLabels           []string             `json:"policyLabels"`
+	ComplianceMetadata     []ComplianceMetadata `json:"complianceMetadata"`
+	ResourceType           string               `json:"resourceType"`
+	Remediable             bool                 `json:"remediable"`
+	CloudType              string               `json:"cloudType"`
+	MittreAttacks          []string             `json:"mittreAttacks"`
+	FindingTypes           []string             `json:"findingTypes"`
+	RestrictAlertDismissal bool                 `json:"restrictAlertDismissal"`
 }
 
 type CountDetails struct {
@@ -40,7 +42,66 @@ type CountDetails struct {
 }
 
 type AlertCount struct {
-	Policies      []Policy    `json:"policies"`
+	Policies      []Policy     `json:"policies"`
 	CountDetails  CountDetails `json:"countDetails"`
-	NextPageToken string      `json:"nextPageToken"`
-}
\ No newline at end of file
+	NextPageToken string       `json:"nextPageToken"`
+}
+
+// Alert structs
+
+type AlertListResponse struct {
+	Total         int     `json:"totalRows"`
+	Items         []Alert `json:"items"`
+	NextPageToken string  `json:"nextPageToken"`
+}
+
+type Alert struct {
+	Id                 string                   `json:"id"`
+	Status             string                   `json:"status"`
+	FirstSeen          int64                    `json:"firstSeen"`
+	LastSeen           int64                    `json:"lastSeen"`
+	AlertTime          int64                    `json:"alertTime"`
+	EventOccurred      int64                    `json:"eventOccurred"`
+	TriggeredBy        string                   `json:"triggeredBy"`
+	AlertCount         int                      `json:"alertCount"`
+	History            []AlertHistory           `json:"history"`
+	Policy             AlertPolicy              `json:"policy"`
+	Risk               alert.RiskDetail         `json:"riskDetail"`
+	Resource           alert.Resource           `json:"resource"`
+	InvestigateOptions alert.InvestigateOptions `json:"investigateOptions"`
+	DismissalNote      string                   `json:"dismissalNote"`
+	DismissedBy        string                   `json:"dismissedBy"`
+	DismissalUntilTs   int64                    `json:"dismissalUntilTs"`
+	AdditionalInfo     map[string]interface{}   `json:"alertAdditionalInfo"`
+	ConnectionDetails  interface{}              `json:"connectionDetails"`
+	NetworkDetails     interface{}              `json:"networkDetails"`
+}
+
+type AlertHistory struct {
+	Reason     string `json:"reason"`
+	Status     string `json:"status"`
+	ModifiedBy string `json:"modifiedBy"`
+	ModifiedOn int64  `json:"modifiedOn"`
+}
+
+type AlertPolicy struct {
+	Id            string           `json:"policyId"`
+	Name          string           `json:"name"`
+	Type          string           `json:"policyType"`
+	Severity      string           `json:"severity"`
+	SystemDefault bool             `json:"systemDefault"`
+	Remediable    bool             `json:"remediable"`
+	Rule          *AlertPolicyRule `json:"rule,omitempty"`
+}
+
+type AlertPolicyRule struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Criteria   interface{}       `json:"criteria"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+type AlertCsvJob struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
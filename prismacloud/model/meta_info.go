@@ -0,0 +1,10 @@
+package model
+
+// MetaInfo represents the tenant information returned by the meta_info endpoint
+// https://pan.dev/prisma-cloud/api/cspm/meta-info/#responses
+type MetaInfo struct {
+	MarketplaceIntegration bool   `json:"marketplaceIntegration"`
+	TwistlockUrl           string `json:"twistlockUrl"`
+	PrismaCloudUrl         string `json:"prismaCloudUrl"`
+	TenantMode             string `json:"tenantMode"`
+}
@@ -0,0 +1,17 @@
+package model
+
+type ResourceInfo struct {
+	Rrn          string      `json:"rrn"`
+	Id           string      `json:"id"`
+	Name         string      `json:"name"`
+	Url          string      `json:"url"`
+	AccountId    string      `json:"accountId"`
+	AccountName  string      `json:"accountName"`
+	CloudType    string      `json:"cloudType"`
+	RegionId     string      `json:"regionId"`
+	RegionName   string      `json:"regionName"`
+	ResourceType string      `json:"resourceType"`
+	InsertTs     int64       `json:"insertTs"`
+	Deleted      bool        `json:"deleted"`
+	Data         interface{} `json:"data"`
+}
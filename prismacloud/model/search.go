@@ -0,0 +1,146 @@
+package model
+
+import "encoding/json"
+
+// Config search structs
+
+type ConfigSearchResponse struct {
+	Id          string                 `json:"id"`
+	Query       string                 `json:"query"`
+	Saved       bool                   `json:"saved"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	SearchType  string                 `json:"searchType"`
+	CloudType   string                 `json:"cloudType"`
+	TimeRange   map[string]interface{} `json:"timeRange"`
+	Data        ConfigSearchData       `json:"data"`
+}
+
+type ConfigSearchData struct {
+	Items         []ConfigSearchItem `json:"items"`
+	NextPageToken string             `json:"nextPageToken"`
+	TotalRows     int                `json:"totalRows"`
+}
+
+type ConfigSearchItem struct {
+	Rrn          string      `json:"rrn"`
+	Id           string      `json:"id"`
+	Name         string      `json:"name"`
+	StateId      string      `json:"stateId"`
+	AccountId    string      `json:"accountId"`
+	AccountName  string      `json:"accountName"`
+	CloudType    string      `json:"cloudType"`
+	RegionId     string      `json:"regionId"`
+	RegionName   string      `json:"regionName"`
+	Service      string      `json:"service"`
+	ResourceType string      `json:"resourceType"`
+	InsertTs     int64       `json:"insertTs"`
+	Deleted      bool        `json:"deleted"`
+	HasAlert     bool        `json:"hasAlert"`
+	HasNetwork   bool        `json:"hasNetwork"`
+	Data         interface{} `json:"data"`
+}
+
+// Event search structs
+
+type EventSearchResponse struct {
+	Id          string                 `json:"id"`
+	Query       string                 `json:"query"`
+	Saved       bool                   `json:"saved"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	SearchType  string                 `json:"searchType"`
+	CloudType   string                 `json:"cloudType"`
+	TimeRange   map[string]interface{} `json:"timeRange"`
+	Data        EventSearchData        `json:"data"`
+}
+
+type EventSearchData struct {
+	Items         []EventSearchItem `json:"items"`
+	NextPageToken string            `json:"nextPageToken"`
+	TotalRows     int               `json:"totalRows"`
+}
+
+type EventSearchItem struct {
+	Id                  int64                  `json:"id"`
+	Subject             string                 `json:"subject"`
+	SubjectType         string                 `json:"subjectType"`
+	Name                string                 `json:"name"`
+	Source              string                 `json:"source"`
+	Type                string                 `json:"type"`
+	Ip                  string                 `json:"ip"`
+	Account             string                 `json:"account"`
+	AccountName         string                 `json:"accountName"`
+	RegionApiIdentifier string                 `json:"regionApiIdentifier"`
+	RegionName          string                 `json:"regionName"`
+	EventTs             int64                  `json:"eventTs"`
+	IngestionTs         int64                  `json:"ingestionTs"`
+	Success             bool                   `json:"success"`
+	Internal            bool                   `json:"internal"`
+	AccessKeyUsed       bool                   `json:"accessKeyUsed"`
+	Role                string                 `json:"role"`
+	Location            string                 `json:"location"`
+	AnomalyId           string                 `json:"anomalyId"`
+	Raw                 map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON keeps the complete event alongside the known fields
+func (e *EventSearchItem) UnmarshalJSON(b []byte) error {
+	type eventSearchItem EventSearchItem
+	var item eventSearchItem
+	if err := json.Unmarshal(b, &item); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &item.Raw); err != nil {
+		return err
+	}
+	*e = EventSearchItem(item)
+	return nil
+}
+
+// Network search structs
+
+type NetworkSearchResponse struct {
+	Id          string                 `json:"id"`
+	Query       string                 `json:"query"`
+	Saved       bool                   `json:"saved"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	SearchType  string                 `json:"searchType"`
+	CloudType   string                 `json:"cloudType"`
+	TimeRange   map[string]interface{} `json:"timeRange"`
+	Data        NetworkSearchData      `json:"data"`
+}
+
+type NetworkSearchData struct {
+	Nodes       []NetworkNode       `json:"nodes"`
+	Connections []NetworkConnection `json:"connections"`
+}
+
+type NetworkNode struct {
+	Id         interface{}            `json:"id"`
+	Name       string                 `json:"name"`
+	IpAddr     string                 `json:"ipAddr"`
+	IconId     string                 `json:"iconId"`
+	Grouped    bool                   `json:"grouped"`
+	Suspicious bool                   `json:"suspicious"`
+	Vulnerable bool                   `json:"vulnerable"`
+	Metadata   map[string]interface{} `json:"metadata"`
+}
+
+type NetworkConnection struct {
+	From       interface{}               `json:"from"`
+	To         interface{}               `json:"to"`
+	Label      string                    `json:"label"`
+	Suspicious bool                      `json:"suspicious"`
+	Metadata   NetworkConnectionMetadata `json:"metadata"`
+}
+
+type NetworkConnectionMetadata struct {
+	BytesAccepted  int64         `json:"bytes_accepted"`
+	BytesRejected  int64         `json:"bytes_rejected"`
+	BytesAttempted int64         `json:"bytes_attempted"`
+	DestPorts      []interface{} `json:"dest_ports"`
+	Protocol       string        `json:"protocol"`
+	FlowClasses    []string      `json:"flow_classes"`
+}
@@ -14,6 +14,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			NewInstance: ConfigInstance,
 		},
 		DefaultTransform: transform.FromCamel(),
+		RateLimiters:     rateLimiters(),
 		DefaultGetConfig: &plugin.GetConfig{
 			IgnoreConfig: &plugin.IgnoreConfig{
 				ShouldIgnoreErrorFunc: isNotFoundError([]string{"object not found"}),
@@ -26,14 +27,25 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			},
 		},
 		TableMap: map[string]*plugin.Table{
-			"prismacloud_account":    tablePrismacloudAccount(ctx),
-			"prismacloud_alert":      tablePrismacloudAlert(ctx),
-			"prismacloud_alert_rule": tablePrismacloudAlertRule(ctx),
+			"prismacloud_account":                                  tablePrismacloudAccount(ctx),
+			"prismacloud_account_child":                            tablePrismacloudAccountChild(ctx),
+			"prismacloud_account_group":                            tablePrismacloudAccountGroup(ctx),
+			"prismacloud_account_group_member":                     tablePrismacloudAccountGroupMember(ctx),
+			"prismacloud_account_status":                           tablePrismacloudAccountStatus(ctx),
+			"prismacloud_alert":                                    tablePrismacloudAlert(ctx),
+			"prismacloud_alert_history":                            tablePrismacloudAlertHistory(ctx),
+			"prismacloud_alert_policy_count":                       tablePrismacloudAlertPolicyCount(ctx),
+			"prismacloud_alert_rule":                               tablePrismacloudAlertRule(ctx),
 			"prismacloud_compliance_breakdown_requirement_summary": tablePrismacloudComplianceBreakdownRequirementSummary(ctx),
 			"prismacloud_compliance_breakdown_statistic":           tablePrismacloudComplianceBreakdownStatistic(ctx),
 			"prismacloud_compliance_breakdown_summary":             tablePrismacloudComplianceBreakdownSummary(ctx),
+			"prismacloud_compliance_coverage":                      tablePrismacloudComplianceCoverage(ctx),
 			"prismacloud_compliance_requirement":                   tablePrismacloudComplianceRequirement(ctx),
+			"prismacloud_compliance_section":                       tablePrismacloudComplianceSection(ctx),
 			"prismacloud_compliance_standard":                      tablePrismacloudComplianceStandard(ctx),
+			"prismacloud_compliance_trend":                         tablePrismacloudComplianceTrend(ctx),
+			"prismacloud_config_search":                            tablePrismacloudConfigSearch(ctx),
+			"prismacloud_event_search":                             tablePrismacloudEventSearch(ctx),
 			"prismacloud_iam_permission":                           tablePrismacloudIAMPermission(ctx),
 			"prismacloud_iam_role":                                 tablePrismacloudIAMRole(ctx),
 			"prismacloud_iam_user":                                 tablePrismacloudIAMUser(ctx),
@@ -43,12 +55,16 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"prismacloud_inventory_workload":                       tablePrismacloudInventoryWorkload(ctx),
 			"prismacloud_inventory_workload_container_image":       tablePrismacloudInventoryWorkloadContainerImage(ctx),
 			"prismacloud_inventory_workload_host":                  tablePrismacloudInventoryWorkloadHost(ctx),
+			"prismacloud_network_search":                           tablePrismacloudNetworkSearch(ctx),
 			"prismacloud_permission_group":                         tablePrismacloudPermissionGroup(ctx),
 			"prismacloud_policy":                                   tablePrismacloudPolicy(ctx),
+			"prismacloud_policy_compliance_mapping":                tablePrismacloudPolicyComplianceMapping(ctx),
 			"prismacloud_prioritized_vulnerability":                tablePrismacloudPrioritizedVulnerability(ctx),
 			"prismacloud_report":                                   tablePrismacloudReport(ctx),
 			"prismacloud_resource":                                 tablePrismacloudResource(ctx),
+			"prismacloud_saved_search":                             tablePrismacloudSavedSearch(ctx),
 			"prismacloud_trusted_alert_ip":                         tablePrismacloudTrustedAlertIp(ctx),
+			"prismacloud_trusted_alert_ip_cidr":                    tablePrismacloudTrustedAlertIpCidr(ctx),
 			"prismacloud_vulnerability_asset":                      tablePrismacloudVulnerabilityAsset(ctx),
 			"prismacloud_vulnerability_burndown":                   tablePrismacloudVulnerabilityBurndown(ctx),
 			"prismacloud_vulnerability_overview":                   tablePrismacloudVulnerabilityOverview(ctx),
@@ -0,0 +1,330 @@
+package prismacloud
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/anywhere"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Test harness shared by the table tests: an in-process mock of the Prisma Cloud APIs,
+// and the plugin started in process with one connection to it.
+
+// mockRequest is a request received by the mock Prisma Cloud API
+type mockRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   map[string]interface{}
+}
+
+// mockHandler returns the status code and the JSON body to respond with
+type mockHandler func(r mockRequest) (int, interface{})
+
+// mockServer is an in-process fake of the Prisma Cloud CSPM and Compute APIs
+type mockServer struct {
+	*httptest.Server
+	t        *testing.T
+	mu       sync.Mutex
+	handlers map[string]mockHandler
+	requests []mockRequest
+}
+
+// newMockServer starts a mock API which accepts any login and serves the current user profile
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+
+	m := &mockServer{
+		t:        t,
+		handlers: map[string]mockHandler{},
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.Close)
+
+	m.handle("POST", "/login", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"token": mockToken(time.Now().Add(10 * time.Minute))}
+	})
+	m.handle("GET", "/auth_token/extend", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"token": mockToken(time.Now().Add(10 * time.Minute))}
+	})
+	m.handleFixture("GET", "/user/me", "user_me.json")
+
+	return m
+}
+
+// handle registers a handler for the method and path
+func (m *mockServer) handle(method, path string, h mockHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[method+" "+path] = h
+}
+
+// handleFixture registers a handler which responds with a recorded JSON fixture from testdata
+func (m *mockServer) handleFixture(method, path, fixture string) {
+	body := loadFixture(m.t, fixture)
+	m.handle(method, path, func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, body
+	})
+}
+
+// handleError registers a handler which responds with a Prisma Cloud error
+func (m *mockServer) handleError(method, path string, status int, i18nKey string) {
+	m.handle(method, path, func(r mockRequest) (int, interface{}) {
+		return status, mockError{i18nKey}
+	})
+}
+
+// received returns the requests received for the method and path
+func (m *mockServer) received(method, path string) []mockRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var requests []mockRequest
+	for _, r := range m.requests {
+		if r.Method == method && r.Path == path {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// host returns the host and port of the mock API, as expected by the url connection option
+func (m *mockServer) host() string {
+	return strings.TrimPrefix(m.URL, "http://")
+}
+
+type mockError struct {
+	i18nKey string
+}
+
+func (m *mockServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r := mockRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+	}
+	if b, _ := io.ReadAll(req.Body); len(b) > 0 {
+		if err := json.Unmarshal(b, &r.Body); err != nil {
+			m.t.Errorf("mock server: invalid JSON body for %s %s: %v", req.Method, req.URL.Path, err)
+		}
+	}
+
+	m.mu.Lock()
+	m.requests = append(m.requests, r)
+	h, ok := m.handlers[req.Method+" "+req.URL.Path]
+	m.mu.Unlock()
+
+	if !ok {
+		m.t.Logf("mock server: no handler for %s %s", req.Method, req.URL.Path)
+		writeMockError(w, http.StatusNotFound, "not_found")
+		return
+	}
+
+	status, body := h(r)
+	if e, ok := body.(mockError); ok {
+		writeMockError(w, status, e.i18nKey)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	switch b := body.(type) {
+	case []byte:
+		w.Write(b)
+	default:
+		json.NewEncoder(w).Encode(b)
+	}
+}
+
+// writeMockError writes an error the way Prisma Cloud does, in the X-Redlock-Status header
+func writeMockError(w http.ResponseWriter, status int, i18nKey string) {
+	w.Header().Set("X-Redlock-Status", fmt.Sprintf(`[{"i18nKey":%q,"severity":"error","subject":null}]`, i18nKey))
+	w.WriteHeader(status)
+}
+
+// mockToken returns an unsigned JWT which expires at the given time
+func mockToken(expiresAt time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, expiresAt.Unix())))
+	return header + "." + payload + ".signature"
+}
+
+// loadFixture reads a recorded JSON response from testdata
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return b
+}
+
+const testConnectionName = "prismacloud_test"
+
+// newTestPlugin starts the plugin in process with one connection to the mock API, logging in with an access key.
+// Extra HCL is appended to the connection configuration.
+func newTestPlugin(t *testing.T, m *mockServer, extraConfig string) *grpc.PluginServer {
+	t.Helper()
+
+	return newTestPluginWithConfig(t, mockConnectionConfig(m)+`
+username = "test-access-key"
+password = "test-secret-key"
+`+extraConfig)
+}
+
+// mockConnectionConfig returns the HCL pointing a connection at the mock API, without credentials
+func mockConnectionConfig(m *mockServer) string {
+	return fmt.Sprintf(`
+url         = %q
+protocol    = "http"
+compute_url = %q
+`, m.host(), m.URL+"/compute")
+}
+
+// newTestPluginWithConfig starts the plugin in process with one connection using the given HCL
+func newTestPluginWithConfig(t *testing.T, config string) *grpc.PluginServer {
+	t.Helper()
+
+	server := plugin.Server(&plugin.ServeOpts{PluginFunc: Plugin})
+
+	res, err := server.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{
+		Configs: []*proto.ConnectionConfig{
+			{
+				Connection:      testConnectionName,
+				Plugin:          "hub.steampipe.io/plugins/turbot/prismacloud@latest",
+				PluginShortName: "prismacloud",
+				Config:          config,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to set connection config: %v", err)
+	}
+	if msg, ok := res.FailedConnections[testConnectionName]; ok {
+		t.Fatalf("failed to set connection config: %s", msg)
+	}
+
+	return server
+}
+
+type testQuery struct {
+	table   string
+	columns []string
+	quals   map[string]*proto.Quals
+	limit   int64
+}
+
+// execute runs the query in process and returns the rows as column values
+func (q testQuery) execute(t *testing.T, server *grpc.PluginServer) ([]map[string]*proto.Column, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	queryContext := &proto.QueryContext{
+		Columns: q.columns,
+		Quals:   q.quals,
+	}
+	connectionData := &proto.ExecuteConnectionData{}
+	if q.limit > 0 {
+		queryContext.Limit = &proto.NullableInt{Value: q.limit}
+		connectionData.Limit = queryContext.Limit
+	}
+
+	stream := anywhere.NewLocalPluginStream(ctx)
+	server.CallExecuteAsync(&proto.ExecuteRequest{
+		Table:                 q.table,
+		QueryContext:          queryContext,
+		Connection:            testConnectionName,
+		CallId:                fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano()),
+		ExecuteConnectionData: map[string]*proto.ExecuteConnectionData{testConnectionName: connectionData},
+	}, stream)
+
+	var rows []map[string]*proto.Column
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return rows, err
+		}
+		if resp == nil {
+			return rows, nil
+		}
+		rows = append(rows, resp.Row.Columns)
+	}
+}
+
+// run starts the plugin against the mock API and runs the query, failing the test on error
+func (q testQuery) run(t *testing.T, m *mockServer) []map[string]*proto.Column {
+	t.Helper()
+
+	rows, err := q.execute(t, newTestPlugin(t, m, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+// quals builds the key column quals of a query
+func quals(qs ...*proto.Qual) map[string]*proto.Quals {
+	res := map[string]*proto.Quals{}
+	for _, q := range qs {
+		if res[q.FieldName] == nil {
+			res[q.FieldName] = &proto.Quals{}
+		}
+		res[q.FieldName].Quals = append(res[q.FieldName].Quals, q)
+	}
+	return res
+}
+
+func stringQual(column, operator, value string) *proto.Qual {
+	return &proto.Qual{
+		FieldName: column,
+		Operator:  &proto.Qual_StringValue{StringValue: operator},
+		Value:     &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: value}},
+	}
+}
+
+func stringListQual(column string, values ...string) *proto.Qual {
+	list := &proto.QualValueList{}
+	for _, v := range values {
+		list.Values = append(list.Values, &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: v}})
+	}
+	return &proto.Qual{
+		FieldName: column,
+		Operator:  &proto.Qual_StringValue{StringValue: "="},
+		Value:     &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}},
+	}
+}
+
+func boolQual(column, operator string, value bool) *proto.Qual {
+	return &proto.Qual{
+		FieldName: column,
+		Operator:  &proto.Qual_StringValue{StringValue: operator},
+		Value:     &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: value}},
+	}
+}
+
+func timestampQual(column, operator string, value time.Time) *proto.Qual {
+	return &proto.Qual{
+		FieldName: column,
+		Operator:  &proto.Qual_StringValue{StringValue: operator},
+		Value:     &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(value)}},
+	}
+}
@@ -0,0 +1,101 @@
+package prismacloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/rate_limiter"
+	"golang.org/x/time/rate"
+)
+
+// Prisma Cloud API families, used as the "service" tag of every hydrate function
+const (
+	serviceAlert         = "alert"
+	serviceCompliance    = "compliance"
+	serviceInventory     = "inventory"
+	serviceIAMSearch     = "iam_search"
+	serviceSearch        = "search"
+	serviceVulnerability = "vulnerability"
+	serviceSettings      = "settings"
+)
+
+type rateLimit struct {
+	FillRate   rate.Limit
+	BucketSize int64
+}
+
+// Default request rate and burst per API family and connection
+var defaultRateLimits = map[string]rateLimit{
+	serviceAlert:         {FillRate: 2, BucketSize: 5},
+	serviceCompliance:    {FillRate: 5, BucketSize: 10},
+	serviceInventory:     {FillRate: 5, BucketSize: 10},
+	serviceIAMSearch:     {FillRate: 2, BucketSize: 5},
+	serviceSearch:        {FillRate: 2, BucketSize: 5},
+	serviceVulnerability: {FillRate: 2, BucketSize: 5},
+	serviceSettings:      {FillRate: 10, BucketSize: 20},
+}
+
+var rateLimitedServices = []string{serviceAlert, serviceCompliance, serviceInventory, serviceIAMSearch, serviceSearch, serviceVulnerability, serviceSettings}
+
+// rateLimiters returns one limiter per API family, scoped per connection.
+// The defaults can be replaced with a limiter block of the same name in the plugin configuration.
+func rateLimiters() []*rate_limiter.Definition {
+	var limiters []*rate_limiter.Definition
+	for _, service := range rateLimitedServices {
+		limit := defaultRateLimits[service]
+		limiters = append(limiters, &rate_limiter.Definition{
+			Name:       "prismacloud_" + service,
+			FillRate:   limit.FillRate,
+			BucketSize: limit.BucketSize,
+			Scope:      []string{"connection", "service"},
+			Where:      fmt.Sprintf("service = '%s'", service),
+		})
+	}
+	return limiters
+}
+
+// serviceTags returns the rate limiter tags for a hydrate function of the given API family
+func serviceTags(service string) map[string]string {
+	return map[string]string{"service": service}
+}
+
+// Serializes the creation of the rate_limit and rate_limit_burst limiters
+var connectionRateLimitersMutex sync.Mutex
+
+// waitForRateLimit waits on the rate_limit and rate_limit_burst overrides of the connection for the given API family.
+// The overrides apply on top of the plugin rate limiters, so they can only lower the rate of a connection.
+// Connections without an override for the family only use the plugin rate limiters.
+func waitForRateLimit(ctx context.Context, d *plugin.QueryData, service string) error {
+	prismacloudConfig := GetConfig(d.Connection)
+
+	fillRate, ok := prismacloudConfig.RateLimit[service]
+	if !ok {
+		return nil
+	}
+	burst, ok := prismacloudConfig.RateLimitBurst[service]
+	if !ok {
+		burst = int(defaultRateLimits[service].BucketSize)
+	}
+
+	connectionName := ""
+	if d.Connection != nil {
+		connectionName = d.Connection.Name
+	}
+
+	// Cache the limiter with the connection, like the client, so it is shared by the queries of the connection.
+	// The key includes the settings so a configuration change starts a new limiter.
+	cacheKey := fmt.Sprintf("prismacloud-rate-limiter-%s-%s-%v-%d", connectionName, service, fillRate, burst)
+	connectionRateLimitersMutex.Lock()
+	var limiter *rate.Limiter
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		limiter = cachedData.(*rate.Limiter)
+	} else {
+		limiter = rate.NewLimiter(rate.Limit(fillRate), burst)
+		d.ConnectionManager.Cache.Set(cacheKey, limiter)
+	}
+	connectionRateLimitersMutex.Unlock()
+
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,39 @@
+package prismacloud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConnectionRateLimit(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/v2/policy", "policies.json")
+	server := newTestPlugin(t, m, `
+rate_limit = {
+  settings = 4
+}
+rate_limit_burst = {
+  settings = 1
+}
+`)
+
+	// Each query lists the policies once, so the second and third wait on the settings limiter of the connection
+	start := time.Now()
+	for _, severity := range []string{"high", "medium", "low"} {
+		_, err := testQuery{
+			table:   "prismacloud_policy",
+			columns: []string{"policy_id"},
+			quals:   quals(stringQual("severity", "=", severity)),
+		}.execute(t, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(m.received("GET", "/v2/policy")) != 3 {
+		t.Fatalf("expected 3 policy list requests, got %d", len(m.received("GET", "/v2/policy")))
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected the rate_limit of the connection to throttle the requests, took %s", elapsed)
+	}
+}
@@ -15,6 +15,10 @@ func tablePrismacloudAccount(ctx context.Context) *plugin.Table {
 		Description: "List all cloud accounts onboarded onto the Prisma Cloud platform.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudAccounts,
+			Tags:    serviceTags(serviceSettings),
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getAccountDetails, Tags: serviceTags(serviceSettings)},
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -46,7 +50,7 @@ func tablePrismacloudAccount(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_ts",
 				Description: "The timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedTs").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedTs"),
 			},
 			{
 				Name:        "last_modified_by",
@@ -81,7 +85,8 @@ func tablePrismacloudAccount(ctx context.Context) *plugin.Table {
 			{
 				Name:        "added_on",
 				Description: "The timestamp when the account was added.",
-				Type:        proto.ColumnType_INT,
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("AddedOn"),
 			},
 			{
 				Name:        "group_ids",
@@ -121,6 +126,10 @@ func listPrismacloudAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	accounts, err := account.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_account.listPrismacloudAccounts", "api_error", err)
@@ -152,6 +161,10 @@ func getAccountDetails(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	account, err := account.Get(conn, accountData.CloudType, accountData.AccountId)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_account.getAccountDetails", "api_error", err)
@@ -0,0 +1,171 @@
+package prismacloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudAccountChild(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_account_child",
+		Description: "List the member accounts of the organizations, tenants and folders onboarded onto Prisma Cloud.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listPrismacloudAccounts,
+			Hydrate:       listPrismacloudAccountChildren,
+			Tags:          serviceTags(serviceSettings),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "parent_account_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "parent_account_id",
+				Description: "The ID of the onboarded organization, tenant or master service account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "parent_account_name",
+				Description: "The name of the onboarded organization, tenant or master service account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_id",
+				Description: "The unique identifier for the member account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the member account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The type of cloud (e.g., AWS, Azure, GCP).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_type",
+				Description: "The type of the member account, such as account, folder or management_group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "enabled",
+				Description: "Indicates if the member account is monitored by Prisma Cloud.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "status",
+				Description: "The onboarding status of the member account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "deployment_type",
+				Description: "The deployment type of the member account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "protection_mode",
+				Description: "The protection mode of the member account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "number_of_child_accounts",
+				Description: "The number of accounts below the member, for folders and management groups.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "added_on",
+				Description: "The timestamp when the member account was added.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("AddedOn"),
+			},
+			{
+				Name:        "last_modified_ts",
+				Description: "The timestamp when the member account was last modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("LastModifiedTs"),
+			},
+			{
+				Name:        "last_modified_by",
+				Description: "The user who last modified the member account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "group_ids",
+				Description: "The IDs of the account groups of the member account.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+// AccountChild is a member account of an onboarded organization
+type AccountChild struct {
+	ParentAccountId   string
+	ParentAccountName string
+	model.CloudOrgAccount
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudAccountChildren(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	parent := h.Item.(account.Account)
+
+	// Only organizations, tenants and master service accounts have member accounts
+	if parent.NumberOfChildAccounts == 0 {
+		return nil, nil
+	}
+	if id := d.EqualsQualString("parent_account_id"); id != "" && id != parent.AccountId {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_child.listPrismacloudAccountChildren", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
+	children, err := api.ListCloudOrgAccounts(conn, strings.ToLower(parent.CloudType), parent.AccountId)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_child.listPrismacloudAccountChildren", "api_error", err)
+		return nil, err
+	}
+
+	for _, child := range children {
+		// The organization itself is listed along with its members
+		if child.AccountId == parent.AccountId {
+			continue
+		}
+
+		d.StreamListItem(ctx, AccountChild{
+			ParentAccountId:   parent.AccountId,
+			ParentAccountName: parent.Name,
+			CloudOrgAccount:   child,
+		})
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
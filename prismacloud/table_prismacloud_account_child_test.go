@@ -0,0 +1,54 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+const testOrgAccounts = `[
+  {"accountId": "111111111111", "name": "aws-org", "cloudType": "aws", "accountType": "organization", "enabled": true, "status": "ok", "numberOfChildAccounts": 2},
+  {"accountId": "123456789012", "name": "aws-prod", "cloudType": "aws", "accountType": "account", "enabled": true, "status": "ok", "numberOfChildAccounts": 0}
+]`
+
+const testOrgChildren = `[
+  {"accountId": "111111111111", "name": "aws-org", "cloudType": "aws", "accountType": "organization", "enabled": true, "status": "ok"},
+  {"accountId": "222222222222", "name": "aws-member", "cloudType": "aws", "accountType": "account", "enabled": true, "status": "ok", "addedOn": 1704067200000},
+  {"accountId": "333333333333", "name": "aws-disabled", "cloudType": "aws", "accountType": "account", "enabled": false, "status": "warning"}
+]`
+
+func TestListAccountChildren(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/cloud", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testOrgAccounts)
+	})
+	m.handle("GET", "/cloud/aws/111111111111/project", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testOrgChildren)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_account_child",
+		columns: []string{"parent_account_id", "parent_account_name", "account_id", "enabled", "status"},
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 member accounts, got %d", len(rows))
+	}
+
+	members := map[string]string{}
+	for _, row := range rows {
+		if row["parent_account_id"].GetStringValue() != "111111111111" || row["parent_account_name"].GetStringValue() != "aws-org" {
+			t.Errorf("unexpected parent: %v", row)
+		}
+		members[row["account_id"].GetStringValue()] = row["status"].GetStringValue()
+	}
+	if members["222222222222"] != "ok" || members["333333333333"] != "warning" {
+		t.Errorf("unexpected members: %v", members)
+	}
+
+	// Accounts without members are not queried
+	if got := len(m.received("GET", "/cloud/aws/123456789012/project")); got != 0 {
+		t.Errorf("expected no member request for a plain account, got %d", got)
+	}
+	if got := m.received("GET", "/cloud/aws/111111111111/project"); len(got) != 1 || got[0].Query.Get("excludeAccountGroupDetails") != "true" {
+		t.Errorf("unexpected member requests: %v", got)
+	}
+}
@@ -0,0 +1,163 @@
+package prismacloud
+
+import (
+	"context"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account/group"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudAccountGroup(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_account_group",
+		Description: "List the cloud account groups of Prisma Cloud.",
+		Get: &plugin.GetConfig{
+			Hydrate:    getPrismacloudAccountGroup,
+			Tags:       serviceTags(serviceSettings),
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudAccountGroups,
+			Tags:    serviceTags(serviceSettings),
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The unique identifier for the account group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the account group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the account group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "parent_group_id",
+				Description: "The ID of the parent account group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ParentInfo.Id").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "parent_group_name",
+				Description: "The name of the parent account group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ParentInfo.Name").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "auto_created",
+				Description: "Indicates if the account group was created automatically when an organization was onboarded.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ParentInfo.AutoCreated"),
+			},
+			{
+				Name:        "last_modified_by",
+				Description: "The user who last modified the account group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_modified_on",
+				Description: "The timestamp when the account group was last modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("LastModifiedTs"),
+			},
+
+			// JSON fields
+			{
+				Name:        "account_ids",
+				Description: "The IDs of the cloud accounts in the account group.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "accounts",
+				Description: "The cloud accounts in the account group.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "child_group_ids",
+				Description: "The IDs of the nested account groups.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "alert_rules",
+				Description: "The alert rules targeting the account group.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudAccountGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_group.listPrismacloudAccountGroups", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
+	// https://pan.dev/prisma-cloud/api/cspm/get-account-groups/
+	groups, err := group.List(conn)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_group.listPrismacloudAccountGroups", "api_error", err)
+		return nil, err
+	}
+
+	for _, g := range groups {
+		d.StreamListItem(ctx, g)
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTION
+
+func getPrismacloudAccountGroup(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+
+	// Empty check
+	if id == "" {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_group.getPrismacloudAccountGroup", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
+	// https://pan.dev/prisma-cloud/api/cspm/get-account-group/
+	g, err := group.Get(conn, id)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_group.getPrismacloudAccountGroup", "api_error", err)
+		return nil, err
+	}
+
+	return g, nil
+}
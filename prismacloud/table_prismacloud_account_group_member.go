@@ -0,0 +1,149 @@
+package prismacloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account/group"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudAccountGroupMember(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_account_group_member",
+		Description: "List the cloud accounts of each Prisma Cloud account group.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudAccountGroupMembers,
+			Tags:    serviceTags(serviceSettings),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "account_group_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "account_group_id",
+				Description: "The ID of the account group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_group_name",
+				Description: "The name of the account group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_id",
+				Description: "The ID of the cloud account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_name",
+				Description: "The name of the cloud account.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountName").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The cloud type of the account, such as aws, azure or gcp.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CloudType").Transform(transform.NullIfZeroValue),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountId"),
+			},
+		}),
+	}
+}
+
+// AccountGroupMember is a cloud account of an account group
+type AccountGroupMember struct {
+	AccountGroupId   string
+	AccountGroupName string
+	AccountId        string
+	AccountName      string
+	CloudType        string
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudAccountGroupMembers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_group_member.listPrismacloudAccountGroupMembers", "connection_error", err)
+		return nil, err
+	}
+
+	var groups []group.Group
+	if ids := equalsQualStrings(d, "account_group_id"); len(ids) > 0 {
+		for _, id := range ids {
+			if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+				return nil, err
+			}
+			g, err := group.Get(conn, id)
+			if err != nil {
+				if strings.Contains(err.Error(), "object not found") {
+					continue
+				}
+				plugin.Logger(ctx).Error("prismacloud_account_group_member.listPrismacloudAccountGroupMembers", "api_error", err)
+				return nil, err
+			}
+			groups = append(groups, g)
+		}
+	} else {
+		if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+			return nil, err
+		}
+		groups, err = group.List(conn)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_account_group_member.listPrismacloudAccountGroupMembers", "api_error", err)
+			return nil, err
+		}
+	}
+
+	for _, g := range groups {
+		for _, member := range accountGroupMembers(g) {
+			d.StreamListItem(ctx, member)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// One member per account ID of the group, with the name and cloud type of the account when the group lists it
+func accountGroupMembers(g group.Group) []AccountGroupMember {
+	accounts := map[string]group.Account{}
+	for _, a := range g.Accounts {
+		accounts[a.Id] = a
+	}
+
+	ids := g.AccountIds
+	if len(ids) == 0 {
+		for _, a := range g.Accounts {
+			ids = append(ids, a.Id)
+		}
+	}
+
+	members := make([]AccountGroupMember, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, AccountGroupMember{
+			AccountGroupId:   g.Id,
+			AccountGroupName: g.Name,
+			AccountId:        id,
+			AccountName:      accounts[id].Name,
+			CloudType:        accounts[id].Type,
+		})
+	}
+	return members
+}
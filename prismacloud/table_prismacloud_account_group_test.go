@@ -0,0 +1,73 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListAccountGroups(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/cloud/group", "account_groups.json")
+
+	rows := testQuery{
+		table:   "prismacloud_account_group",
+		columns: []string{"id", "name", "parent_group_id", "account_ids"},
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 account groups, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["id"].GetStringValue() == "group-1" {
+			if row["parent_group_id"].GetStringValue() != "group-root" {
+				t.Errorf("unexpected parent group: %v", row["parent_group_id"])
+			}
+			if got := string(row["account_ids"].GetJsonValue()); got != `["123456789012","210987654321"]` {
+				t.Errorf("unexpected account ids: %s", got)
+			}
+		}
+	}
+}
+
+func TestListAccountGroupMembers(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/cloud/group", "account_groups.json")
+
+	rows := testQuery{
+		table:   "prismacloud_account_group_member",
+		columns: []string{"account_group_id", "account_group_name", "account_id", "account_name", "cloud_type"},
+	}.run(t, m)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(rows))
+	}
+
+	members := map[string]string{}
+	for _, row := range rows {
+		members[row["account_id"].GetStringValue()] = row["account_group_name"].GetStringValue() + "/" + row["account_name"].GetStringValue() + "/" + row["cloud_type"].GetStringValue()
+	}
+	if members["210987654321"] != "Production/aws-prod-eu/aws" || members["345678901234"] != "Sandbox/azure-sandbox/azure" {
+		t.Errorf("unexpected members: %v", members)
+	}
+}
+
+func TestListAccountGroupMembersByGroupId(t *testing.T) {
+	m := newMockServer(t)
+	groups := loadFixture(t, "account_groups.json")
+	m.handle("GET", "/cloud/group/group-2", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`{"id": "group-2", "name": "Sandbox", "accountIds": ["345678901234"]}`)
+	})
+	m.handle("GET", "/cloud/group", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, groups
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_account_group_member",
+		columns: []string{"account_group_id", "account_id", "account_name"},
+		quals:   quals(stringQual("account_group_id", "=", "group-2")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["account_id"].GetStringValue() != "345678901234" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if n := len(m.received("GET", "/cloud/group")); n != 0 {
+		t.Errorf("expected the group to be fetched by id, got %d list requests", n)
+	}
+}
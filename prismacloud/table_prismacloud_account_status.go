@@ -0,0 +1,196 @@
+package prismacloud
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudAccountStatus(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_account_status",
+		Description: "List the onboarding health of each component of the cloud accounts onboarded onto Prisma Cloud.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listPrismacloudAccounts,
+			Hydrate:       listPrismacloudAccountStatuses,
+			Tags:          serviceTags(serviceSettings),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "account_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "account_id",
+				Description: "The unique identifier for the account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_name",
+				Description: "The name of the account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The type of cloud (e.g., AWS, Azure, GCP).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_enabled",
+				Description: "Indicates if the account is enabled.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "component",
+				Description: "The name of the component, such as Config, Flow Logs, Audit Events, Data Security or Agentless Scanning.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "parent_component",
+				Description: "The name of the component this sub-component belongs to. Null for top level components.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ParentComponent").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "status",
+				Description: "The health of the component, such as ok, warning or error.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "message",
+				Description: "The error or warning message of the component.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Message").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "remediation",
+				Description: "The remediation steps reported for the component.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "last_ingested_on",
+				Description: "The timestamp of the last successful ingestion of the component, when reported.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("LastIngestedTs"),
+			},
+			{
+				Name:        "last_updated_on",
+				Description: "The timestamp when the status of the component was last updated, when reported.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("LastUpdatedTs"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Component"),
+			},
+		}),
+	}
+}
+
+// AccountStatus is the health of a component of a cloud account
+type AccountStatus struct {
+	AccountId       string
+	AccountName     string
+	CloudType       string
+	AccountEnabled  bool
+	Component       string
+	ParentComponent string
+	Status          string
+	Message         string
+	Remediation     interface{}
+	LastIngestedTs  int64
+	LastUpdatedTs   int64
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudAccountStatuses(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	acc := h.Item.(account.Account)
+
+	if id := d.EqualsQualString("account_id"); id != "" && id != acc.AccountId {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_account_status.listPrismacloudAccountStatuses", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
+	components, err := api.GetAccountConfigStatus(conn, acc.AccountId)
+	if err != nil {
+		// Some account types, such as organization members, have no status of their own
+		if strings.Contains(err.Error(), "object not found") {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("prismacloud_account_status.listPrismacloudAccountStatuses", "api_error", err)
+		return nil, err
+	}
+
+	for _, item := range flattenAccountStatus(acc, components, "") {
+		d.StreamListItem(ctx, item)
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// Flatten the components of an account and their sub-components into one row each
+func flattenAccountStatus(acc account.Account, components []model.AccountComponentStatus, parent string) []AccountStatus {
+	var items []AccountStatus
+	for _, c := range components {
+		items = append(items, AccountStatus{
+			AccountId:       acc.AccountId,
+			AccountName:     acc.Name,
+			CloudType:       acc.CloudType,
+			AccountEnabled:  acc.Enabled,
+			Component:       c.Name,
+			ParentComponent: parent,
+			Status:          c.Status,
+			Message:         accountStatusMessage(c.Message),
+			Remediation:     c.Remediation,
+			LastIngestedTs:  c.LastIngestedTs,
+			LastUpdatedTs:   c.LastUpdatedTs,
+		})
+		items = append(items, flattenAccountStatus(acc, c.SubComponents, c.Name)...)
+	}
+	return items
+}
+
+// The message of a component is either plain text or an object holding the text along with a message code
+func accountStatusMessage(message interface{}) string {
+	switch m := message.(type) {
+	case nil:
+		return ""
+	case string:
+		return m
+	case map[string]interface{}:
+		if text, ok := m["message"].(string); ok {
+			return text
+		}
+	}
+	b, err := json.Marshal(message)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
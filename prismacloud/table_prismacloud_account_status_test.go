@@ -0,0 +1,50 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListAccountStatuses(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/cloud", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"accountId": "123456789012", "name": "aws-prod", "cloudType": "aws", "enabled": true}]`)
+	})
+	m.handle("GET", "/account/123456789012/config/status", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[
+  {"name": "Config", "status": "ok", "lastIngestedTs": 1714521600000},
+  {"name": "Flow Logs", "status": "error", "message": {"message": "Flow logs are not enabled for 2 VPCs", "messageCode": "flow_logs_disabled"},
+   "subComponents": [{"name": "us-east-1", "status": "error", "message": "No flow logs found"}]}
+]`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_account_status",
+		columns: []string{"account_id", "account_name", "component", "parent_component", "status", "message", "last_ingested_on"},
+	}.run(t, m)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 components, got %d", len(rows))
+	}
+
+	for _, row := range rows {
+		if row["account_name"].GetStringValue() != "aws-prod" {
+			t.Errorf("unexpected account: %v", row["account_name"])
+		}
+		switch row["component"].GetStringValue() {
+		case "Config":
+			if row["last_ingested_on"].GetTimestampValue().AsTime().UnixMilli() != 1714521600000 {
+				t.Errorf("unexpected last ingestion: %v", row["last_ingested_on"])
+			}
+		case "Flow Logs":
+			if row["message"].GetStringValue() != "Flow logs are not enabled for 2 VPCs" {
+				t.Errorf("unexpected message: %v", row["message"])
+			}
+		case "us-east-1":
+			if row["parent_component"].GetStringValue() != "Flow Logs" || row["message"].GetStringValue() != "No flow logs found" {
+				t.Errorf("unexpected sub-component: %v", row)
+			}
+		default:
+			t.Errorf("unexpected component: %v", row["component"])
+		}
+	}
+}
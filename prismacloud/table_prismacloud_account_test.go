@@ -0,0 +1,23 @@
+package prismacloud
+
+import (
+	"testing"
+)
+
+func TestListAccounts(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/cloud", "cloud_accounts.json")
+
+	rows := testQuery{table: "prismacloud_account", columns: []string{"account_id", "name", "email"}}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["email"].GetStringValue() != "jane.doe@example.com" {
+			t.Errorf("expected the email connection key column on every row, got %v", row["email"])
+		}
+	}
+	if len(m.received("POST", "/login")) != 1 {
+		t.Errorf("expected a single login, got %d", len(m.received("POST", "/login")))
+	}
+}
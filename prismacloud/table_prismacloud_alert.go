@@ -2,10 +2,13 @@ package prismacloud
 
 import (
 	"context"
-	"time"
+	"fmt"
+	"strings"
 
 	"github.com/paloaltonetworks/prisma-cloud-go/alert"
 	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -18,21 +21,36 @@ func tablePrismacloudAlert(ctx context.Context) *plugin.Table {
 		Description: "List all information for prima cloud alerts.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudAlert,
+			Tags:       serviceTags(serviceAlert),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudAlerts,
+			Tags:    serviceTags(serviceAlert),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "alert_time", Require: plugin.Optional, Operators: []string{"=", ">=", "<=", ">", "<"}},
-				{Name: "status", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "time_range", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "status", Require: plugin.Optional, Operators: []string{"=", "<>"}},
 				{Name: "policy_id", Require: plugin.Optional, Operators: []string{"="}},
 				{Name: "policy_type", Require: plugin.Optional, Operators: []string{"="}},
 				{Name: "policy_remediable", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+				{Name: "policy_severity", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+				{Name: "policy_name", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "cloud_account_id", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "cloud_region", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "resource_type", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "resource_id", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "resource_name", Require: plugin.Optional, Operators: []string{"="}},
+				{Name: "alert_rule_name", Require: plugin.Optional, Operators: []string{"="}, CacheMatch: query_cache.CacheMatchExact},
 				{Name: "policy_compliance_standard_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 				{Name: "policy_compliance_requirement_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 				{Name: "policy_compliance_section_id", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getPrismacloudAlertResourceConfig, Tags: serviceTags(serviceInventory)},
+			{Func: getPrismacloudAlertEvidence, Tags: serviceTags(serviceAlert)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "id",
@@ -48,19 +66,25 @@ func tablePrismacloudAlert(ctx context.Context) *plugin.Table {
 				Name:        "first_seen",
 				Description: "The timestamp when the alert was first seen.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("FirstSeen").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("FirstSeen"),
 			},
 			{
 				Name:        "last_seen",
 				Description: "The timestamp when the alert was last seen.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastSeen").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastSeen"),
 			},
 			{
 				Name:        "alert_time",
 				Description: "The timestamp when the alert was triggered.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("AlertTime").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("AlertTime"),
+			},
+			{
+				Name:        "time_range",
+				Description: "The relative time range of the alert search, such as '24 hours', '7 days', 'epoch' or 'login'. Ignored when alert_time is used.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("time_range"),
 			},
 			{
 				Name:        "policy_compliance_standard_name",
@@ -84,7 +108,7 @@ func tablePrismacloudAlert(ctx context.Context) *plugin.Table {
 				Name:        "event_occurred",
 				Description: "The timestamp when the event occurred.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("EventOccurred").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("EventOccurred"),
 			},
 			{
 				Name:        "triggered_by",
@@ -119,6 +143,18 @@ func tablePrismacloudAlert(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_BOOL,
 				Transform:   transform.FromField("Policy.Remediable"),
 			},
+			{
+				Name:        "policy_name",
+				Description: "The name of the policy associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Policy.Name"),
+			},
+			{
+				Name:        "policy_severity",
+				Description: "The severity of the policy associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Policy.Severity"),
+			},
 			{
 				Name:        "policy_system_default",
 				Description: "If the policy associated with the alert is system default.",
@@ -131,6 +167,42 @@ func tablePrismacloudAlert(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Risk"),
 			},
+			{
+				Name:        "cloud_account_id",
+				Description: "The ID of the cloud account of the resource associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Resource.AccountId"),
+			},
+			{
+				Name:        "cloud_region",
+				Description: "The name of the cloud region of the resource associated with the alert, for example AWS Virginia.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Resource.Region"),
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Resource.ResourceType"),
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Resource.Id"),
+			},
+			{
+				Name:        "resource_name",
+				Description: "The name of the resource associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Resource.Name"),
+			},
+			{
+				Name:        "alert_rule_name",
+				Description: "The name of the alert rule which generated the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("alert_rule_name"),
+			},
 			{
 				Name:        "resource",
 				Description: "The resource associated with the alert.",
@@ -141,6 +213,20 @@ func tablePrismacloudAlert(ctx context.Context) *plugin.Table {
 				Description: "Options for investigating the alert.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "resource_config",
+				Description: "The raw JSON configuration of the resource at alert time, or the latest configuration when the alert has no snapshot.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getPrismacloudAlertResourceConfig,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "evidence",
+				Description: "The evidence of the alert, including the criteria of the policy rule, its parameters and the violating attributes of the resource.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getPrismacloudAlertEvidence,
+				Transform:   transform.FromValue(),
+			},
 
 			// Steampipe standard columns
 			{
@@ -162,6 +248,10 @@ func listPrismacloudAlerts(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+		return nil, err
+	}
+
 	// https://pan.dev/prisma-cloud/api/cspm/get-alerts-v-2/
 	// Limiting the results
 	maxLimit := int32(10000)
@@ -172,15 +262,14 @@ func listPrismacloudAlerts(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		}
 	}
 
-	timeRange := timerange.Absolute{
-		End: int(time.Now().UnixMilli()),
-	}
-	st, et := getAlertStartTImeAndSearchEndTime(d)
-	if st != 0 {
-		timeRange.Start = int(st)
+	timeRange, err := timeRangeValueFromQuals(d, "alert_time")
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_alert.listPrismacloudAlerts", "time_range_error", err)
+		return nil, err
 	}
-	if et != 0 {
-		timeRange.End = int(et)
+	// The alert_time quals can't match any alert
+	if timeRange == nil {
+		return nil, nil
 	}
 
 	req := alert.Request{
@@ -193,17 +282,34 @@ func listPrismacloudAlerts(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		},
 	}
 
-	filter := getAlertFilter(d)
+	// No alert can match when the quals exclude every value of a filter
+	filter, ok := getAlertFilter(d)
+	if !ok {
+		return nil, nil
+	}
 	if len(filter) > 0 {
 		req.Filters = filter
 	}
 
-	alerts, err := alert.List(conn, req)
+	// Large result sets are read from an asynchronous alert CSV job instead of being paged
+	mode, err := alertExportMode(d)
+	if err != nil {
+		return nil, err
+	}
+	if mode == alertExportModeAsync && (d.QueryContext.Limit == nil || *d.QueryContext.Limit > int64(maxLimit)) {
+		if err := listPrismacloudAlertsExport(ctx, d, conn, req); err != nil {
+			plugin.Logger(ctx).Error("prismacloud_alert.listPrismacloudAlerts", "export_error", err)
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	alerts, err := api.ListAlerts(conn, req)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_alert.listPrismacloudAlerts", "api_error", err)
 		return nil, err
 	}
-	for _, alert := range alerts.Data {
+	for _, alert := range alerts.Items {
 
 		d.StreamListItem(ctx, alert)
 		// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -213,16 +319,16 @@ func listPrismacloudAlerts(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 
 	}
 
-	for alerts.PageToken != "" {
+	for alerts.NextPageToken != "" {
 		req.Offset = req.Offset + alerts.Total
-		req.PageToken = alerts.PageToken
+		req.PageToken = alerts.NextPageToken
 
-		alerts, err = alert.List(conn, req)
+		alerts, err = api.ListAlerts(conn, req)
 		if err != nil {
 			plugin.Logger(ctx).Error("prismacloud_alert.listPrismacloudAlerts", "api_paging_error", err)
 			return nil, err
 		}
-		for _, alert := range alerts.Data {
+		for _, alert := range alerts.Items {
 
 			d.StreamListItem(ctx, alert)
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -251,7 +357,11 @@ func getPrismacloudAlert(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, err
 	}
 
-	alert, err := alert.Get(conn, id)
+	if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+		return nil, err
+	}
+
+	alert, err := api.GetAlert(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_alert.getPrismacloudAlert", "api_error", err)
 		return nil, err
@@ -260,75 +370,190 @@ func getPrismacloudAlert(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	return alert, nil
 }
 
+// The raw configuration of the resource is part of the detailed alert payload.
+// The resource is only fetched by its RRN when the alert has no snapshot of it.
+func getPrismacloudAlertResourceConfig(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	a := alertFromHydrateItem(h.Item)
+	if a.Resource.Data != nil {
+		return a.Resource.Data, nil
+	}
+	if a.Resource.Rrn == "" {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_alert.getPrismacloudAlertResourceConfig", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
+	resource, err := api.GetResourceByRrn(conn, a.Resource.Rrn)
+	if err != nil {
+		// The resource may have been deleted since the alert was raised
+		if strings.Contains(err.Error(), "object not found") {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("prismacloud_alert.getPrismacloudAlertResourceConfig", "api_error", err)
+		return nil, err
+	}
+
+	return resource.Data, nil
+}
+
+// AlertEvidence is what matched the policy for an alert
+type AlertEvidence struct {
+	Criteria          interface{}            `json:"criteria,omitempty"`
+	RuleType          string                 `json:"ruleType,omitempty"`
+	Parameters        map[string]string      `json:"parameters,omitempty"`
+	AdditionalInfo    map[string]interface{} `json:"additionalInfo,omitempty"`
+	ConnectionDetails interface{}            `json:"connectionDetails,omitempty"`
+	NetworkDetails    interface{}            `json:"networkDetails,omitempty"`
+}
+
+// The evidence is read from the detailed alert. The alert info is only fetched when the row
+// has no policy rule, since both the detailed alert list and the get config return it.
+func getPrismacloudAlertEvidence(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	a := alertFromHydrateItem(h.Item)
+	if _, fetched := h.Item.(*model.Alert); !fetched && a.Policy.Rule == nil {
+		conn, err := connect(ctx, d)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_alert.getPrismacloudAlertEvidence", "connection_error", err)
+			return nil, err
+		}
+
+		if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+			return nil, err
+		}
+
+		a, err = api.GetAlert(conn, a.Id)
+		if err != nil {
+			if strings.Contains(err.Error(), "object not found") {
+				return nil, nil
+			}
+			plugin.Logger(ctx).Error("prismacloud_alert.getPrismacloudAlertEvidence", "api_error", err)
+			return nil, err
+		}
+	}
+
+	evidence := AlertEvidence{
+		AdditionalInfo:    a.AdditionalInfo,
+		ConnectionDetails: a.ConnectionDetails,
+		NetworkDetails:    a.NetworkDetails,
+	}
+	if a.Policy.Rule != nil {
+		evidence.Criteria = a.Policy.Rule.Criteria
+		evidence.RuleType = a.Policy.Rule.Type
+		evidence.Parameters = a.Policy.Rule.Parameters
+	}
+	if evidence.Criteria == nil && len(evidence.AdditionalInfo) == 0 && evidence.ConnectionDetails == nil && evidence.NetworkDetails == nil {
+		return nil, nil
+	}
+
+	return evidence, nil
+}
+
 //// UTILITY FUNCTION
 
-// Build the filter parameter
+// Rows of the list config hold alerts, while the get config returns a pointer to the alert
+func alertFromHydrateItem(item interface{}) *model.Alert {
+	if a, ok := item.(*model.Alert); ok {
+		return a
+	}
+	a := item.(model.Alert)
+	return &a
+}
+
+// Alert filters for the key columns, in the order they are sent
+var alertFilterColumns = []struct {
+	column string
+	filter string
+}{
+	{"status", "alert.status"},
+	{"policy_id", "policy.id"},
+	{"policy_type", "policy.type"},
+	{"policy_remediable", "policy.remediable"},
+	{"policy_severity", "policy.severity"},
+	{"policy_name", "policy.name"},
+	{"policy_compliance_standard_name", "policy.complianceStandard"},
+	{"policy_compliance_requirement_name", "policy.complianceRequirement"},
+	{"policy_compliance_section_id", "policy.complianceSection"},
+	{"cloud_account_id", "cloud.accountId"},
+	{"cloud_region", "cloud.region"},
+	{"resource_type", "resource.type"},
+	{"resource_id", "resource.id"},
+	{"resource_name", "resource.name"},
+	{"alert_rule_name", "alertRule.name"},
+}
 
-func getAlertFilter(keyQuals *plugin.QueryData) []alert.Filter {
+// The alert filters only match values, so a <> qual is sent as one filter per remaining value
+var alertFilterValues = map[string][]string{
+	"status":          {"open", "resolved", "dismissed", "snoozed", "pending_resolution"},
+	"policy_severity": {"critical", "high", "medium", "low", "informational"},
+}
+
+// Build the filter parameter, or return false when the quals exclude every value of a filter.
+// Filters with the same name match any of their values, so an IN list is sent as one filter per value.
+func getAlertFilter(d *plugin.QueryData) ([]alert.Filter, bool) {
 	var filter []alert.Filter
 
-	qualsMap := map[string]string{
-		"status":                             "alert.status",
-		"policy_id":                          "policy.id",
-		"policy_type":                        "policy.type",
-		"policy_remediable":                  "policy.remediable",
-		"policy_compliance_standard_name":    "policy.complianceStandard",
-		"policy_compliance_requirement_name": "policy.complianceRequirement",
-		"policy_compliance_section_id":       "policy.complianceSection",
-	}
-
-	for columnName, filterValue := range qualsMap {
-		if keyQuals.Quals[columnName] != nil {
-			if columnName == "policy_remediable" {
-				f := alert.Filter{
-					Name:     filterValue,
-					Operator: "=",
-					Value:    "",
-				}
-				for _, q := range keyQuals.Quals[columnName].Quals {
-					if q.Operator == "=" {
-						f.Value = "true"
-					}
-					if q.Operator == "<>" {
-						f.Value = "false"
-					}
-				}
-				filter = append(filter, f)
-				continue
-			}
-			f := alert.Filter{
-				Name:     filterValue,
-				Operator: "=",
-				Value:    "",
-			}
-			for _, q := range keyQuals.Quals[columnName].Quals {
-				if q.Operator == "=" {
-					f.Value = q.Value.GetStringValue()
+	for _, fc := range alertFilterColumns {
+		if d.Quals[fc.column] == nil {
+			continue
+		}
+
+		if fc.column == "policy_remediable" {
+			for _, q := range d.Quals[fc.column].Quals {
+				remediable := q.Value.GetBoolValue()
+				if q.Operator == "<>" {
+					remediable = !remediable
 				}
+				filter = append(filter, alert.Filter{Name: fc.filter, Operator: "=", Value: fmt.Sprint(remediable)})
 			}
+			continue
+		}
 
-			filter = append(filter, f)
+		values, ok := alertFilterQualValues(d, fc.column)
+		if !ok {
+			return nil, false
+		}
+		for _, value := range values {
+			filter = append(filter, alert.Filter{Name: fc.filter, Operator: "=", Value: value})
 		}
 	}
-	return filter
-}
 
-func getAlertStartTImeAndSearchEndTime(keyQuals *plugin.QueryData) (int64, int64) {
+	return filter, true
+}
 
-	st, et := int64(0), int64(0)
+// Returns the values matching the = and <> quals on a filter column,
+// or false when the <> quals exclude every value the column can match
+func alertFilterQualValues(d *plugin.QueryData, columnName string) ([]string, bool) {
+	values := equalsQualStrings(d, columnName)
 
-	if keyQuals.Quals["alert_time"] != nil && !(len(keyQuals.Quals["alert_time"].Quals) > 1) {
-		for _, q := range keyQuals.Quals["alert_time"].Quals {
-			t := q.Value.GetTimestampValue().AsTime()
-			switch q.Operator {
-			case "=", ">=", ">":
-				st = t.UnixMilli()
-				et = time.Now().UnixMilli()
-			case "<", "<=":
-				et = t.UnixMilli()
-			}
+	excluded := map[string]bool{}
+	for _, q := range d.Quals[columnName].Quals {
+		if q.Operator == "<>" {
+			excluded[strings.ToLower(q.Value.GetStringValue())] = true
 		}
 	}
+	if len(excluded) == 0 {
+		return values, true
+	}
 
-	return st, et
+	if len(values) == 0 {
+		values = alertFilterValues[columnName]
+	}
+	if len(values) == 0 {
+		return nil, true
+	}
+	var remaining []string
+	for _, v := range values {
+		if !excluded[strings.ToLower(v)] {
+			remaining = append(remaining, v)
+		}
+	}
+	return remaining, len(remaining) > 0
 }
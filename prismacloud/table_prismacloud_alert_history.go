@@ -0,0 +1,253 @@
+package prismacloud
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/alert"
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudAlertHistory(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_alert_history",
+		Description: "List the status transitions of Prisma Cloud alerts.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudAlertHistory,
+			Tags:    serviceTags(serviceAlert),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "alert_id", Require: plugin.Optional},
+				{Name: "alert_status", Require: plugin.Optional},
+				{Name: "policy_id", Require: plugin.Optional},
+				{Name: "cloud_account_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "alert_id",
+				Description: "The unique identifier for the alert.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "previous_status",
+				Description: "The status of the alert before the transition. Null for the first transition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PreviousStatus").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "status",
+				Description: "The status of the alert after the transition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "reason",
+				Description: "The reason of the transition, for example NEW_ALERT, RESOURCE_DELETED or USER_DISMISSED.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "modified_by",
+				Description: "The user or system which made the transition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "modified_on",
+				Description: "The timestamp of the transition.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("ModifiedOn"),
+			},
+			{
+				Name:        "dismissal_note",
+				Description: "The note given when the alert was dismissed or snoozed. Only set on the latest transition of a dismissed or snoozed alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DismissalNote").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "snoozed_until",
+				Description: "The timestamp when the snooze expires. Only set on the latest transition of a snoozed alert.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("SnoozedUntil"),
+			},
+			{
+				Name:        "alert_status",
+				Description: "The current status of the alert.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_id",
+				Description: "The ID of the policy associated with the alert.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cloud_account_id",
+				Description: "The ID of the cloud account of the alerted resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AlertId"),
+			},
+		}),
+	}
+}
+
+// AlertHistoryItem is a status transition of an alert, along with the alert it belongs to
+type AlertHistoryItem struct {
+	AlertId        string
+	PreviousStatus string
+	Status         string
+	Reason         string
+	ModifiedBy     string
+	ModifiedOn     int64
+	DismissalNote  string
+	SnoozedUntil   int64
+	AlertStatus    string
+	PolicyId       string
+	CloudAccountId string
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudAlertHistory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_alert_history.listPrismacloudAlertHistory", "connection_error", err)
+		return nil, err
+	}
+
+	// The detailed alert payload includes the history, so an alert is fetched by ID when possible
+	if ids := equalsQualStrings(d, "alert_id"); len(ids) > 0 {
+		for _, id := range ids {
+			if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+				return nil, err
+			}
+
+			a, err := api.GetAlert(conn, id)
+			if err != nil {
+				// Skip IDs of alerts which don't exist, the same way the get config of the alert table does
+				if strings.Contains(err.Error(), "object not found") {
+					continue
+				}
+				plugin.Logger(ctx).Error("prismacloud_alert_history.listPrismacloudAlertHistory", "api_error", err)
+				return nil, err
+			}
+
+			for _, item := range flattenAlertHistory(a) {
+				d.StreamListItem(ctx, item)
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	// https://pan.dev/prisma-cloud/api/cspm/post-alerts-v-2/
+	req := alert.Request{
+		Limit:    10000,
+		Detailed: true,
+		TimeRange: timerange.TimeRange{
+			Value: timerange.Epoch,
+		},
+		Filters: alertHistoryFilter(d),
+	}
+
+	for {
+		if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+			return nil, err
+		}
+
+		alerts, err := api.ListAlerts(conn, req)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_alert_history.listPrismacloudAlertHistory", "api_error", err)
+			return nil, err
+		}
+
+		for i := range alerts.Items {
+			for _, item := range flattenAlertHistory(&alerts.Items[i]) {
+				d.StreamListItem(ctx, item)
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+
+		if alerts.NextPageToken == "" {
+			break
+		}
+		req.Offset = req.Offset + alerts.Total
+		req.PageToken = alerts.NextPageToken
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// Build the alert filters from the quals on the alert columns
+func alertHistoryFilter(d *plugin.QueryData) []alert.Filter {
+	var filter []alert.Filter
+	for _, fc := range []struct {
+		column string
+		filter string
+	}{
+		{"alert_status", "alert.status"},
+		{"policy_id", "policy.id"},
+		{"cloud_account_id", "cloud.accountId"},
+	} {
+		for _, value := range equalsQualStrings(d, fc.column) {
+			filter = append(filter, alert.Filter{Name: fc.filter, Operator: "=", Value: value})
+		}
+	}
+	return filter
+}
+
+// Flatten the history of an alert into one row per status transition, oldest first.
+// The dismissal note and snooze expiry of the alert only describe its latest transition.
+func flattenAlertHistory(a *model.Alert) []AlertHistoryItem {
+	history := make([]model.AlertHistory, len(a.History))
+	copy(history, a.History)
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].ModifiedOn < history[j].ModifiedOn
+	})
+
+	items := make([]AlertHistoryItem, 0, len(history))
+	previousStatus := ""
+	for _, h := range history {
+		items = append(items, AlertHistoryItem{
+			AlertId:        a.Id,
+			PreviousStatus: previousStatus,
+			Status:         h.Status,
+			Reason:         h.Reason,
+			ModifiedBy:     h.ModifiedBy,
+			ModifiedOn:     h.ModifiedOn,
+			AlertStatus:    a.Status,
+			PolicyId:       a.Policy.Id,
+			CloudAccountId: a.Resource.AccountId,
+		})
+		previousStatus = h.Status
+	}
+
+	if len(items) > 0 {
+		latest := &items[len(items)-1]
+		if latest.Status == a.Status && (a.Status == "dismissed" || a.Status == "snoozed") {
+			latest.DismissalNote = a.DismissalNote
+		}
+		if latest.Status == a.Status && a.Status == "snoozed" {
+			latest.SnoozedUntil = a.DismissalUntilTs
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,87 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestListAlertHistoryByAlertId(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/alert/P-2001", "alert_detailed.json")
+	m.handleError("GET", "/alert/P-404", http.StatusBadRequest, "not_found")
+
+	rows := testQuery{
+		table:   "prismacloud_alert_history",
+		columns: []string{"alert_id", "previous_status", "status", "reason", "modified_on", "dismissal_note", "snoozed_until", "policy_id"},
+		quals:   quals(stringListQual("alert_id", "P-2001", "P-404")),
+	}.run(t, m)
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 transitions, got %d", len(rows))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i]["modified_on"].GetTimestampValue().AsTime().Before(rows[j]["modified_on"].GetTimestampValue().AsTime())
+	})
+
+	expected := []struct{ previous, status string }{
+		{"", "open"},
+		{"open", "resolved"},
+		{"resolved", "open"},
+		{"open", "snoozed"},
+	}
+	for i, e := range expected {
+		if got := rows[i]["previous_status"].GetStringValue(); got != e.previous {
+			t.Errorf("row %d: expected previous status %q, got %q", i, e.previous, got)
+		}
+		if got := rows[i]["status"].GetStringValue(); got != e.status {
+			t.Errorf("row %d: expected status %q, got %q", i, e.status, got)
+		}
+	}
+
+	for i, row := range rows {
+		note := row["dismissal_note"].GetStringValue()
+		if i == len(rows)-1 {
+			if note != "Waiting for the maintenance window" {
+				t.Errorf("expected the dismissal note on the latest transition, got %q", note)
+			}
+			if got := row["snoozed_until"].GetTimestampValue().AsTime(); !got.Equal(time.UnixMilli(1717200000000)) {
+				t.Errorf("expected the snooze expiry on the latest transition, got %v", got)
+			}
+		} else if note != "" {
+			t.Errorf("row %d: expected no dismissal note, got %q", i, note)
+		}
+	}
+}
+
+func TestListAlertHistoryFilters(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+
+	testQuery{
+		table:   "prismacloud_alert_history",
+		columns: []string{"alert_id", "status"},
+		quals: quals(
+			stringQual("policy_id", "=", "policy-1"),
+			stringQual("cloud_account_id", "=", "123456789012"),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/v2/alert")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 alert list request, got %d", len(requests))
+	}
+	body := requests[0].Body
+	if body["detailed"] != true {
+		t.Error("expected detailed alerts to be requested")
+	}
+
+	filters := map[string]string{}
+	for _, f := range body["filters"].([]interface{}) {
+		f := f.(map[string]interface{})
+		filters[f["name"].(string)] = f["value"].(string)
+	}
+	if filters["policy.id"] != "policy-1" || filters["cloud.accountId"] != "123456789012" {
+		t.Errorf("unexpected filters: %v", filters)
+	}
+}
@@ -0,0 +1,215 @@
+package prismacloud
+
+import (
+	"context"
+	"strings"
+
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
+)
+
+func tablePrismacloudAlertPolicyCount(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_alert_policy_count",
+		Description: "Count the Prisma Cloud alerts of each policy.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudAlertPolicyCounts,
+			Tags:    serviceTags(serviceAlert),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "alert_status", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "severity", Require: plugin.Optional},
+				{Name: "cloud_type", Require: plugin.Optional},
+				{Name: "account_group", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "time_range", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "policy_id",
+				Description: "The unique identifier for the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_name",
+				Description: "The name of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alert_count",
+				Description: "The number of alerts of the policy matching the quals.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "alert_status",
+				Description: "The status of the counted alerts, such as open, resolved, dismissed or snoozed. All statuses are counted when not set.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("alert_status"),
+			},
+			{
+				Name:        "severity",
+				Description: "The severity of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_type",
+				Description: "The type of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The cloud type of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_group",
+				Description: "The name of the account group the counted alerts belong to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("account_group"),
+			},
+			{
+				Name:        "time_range",
+				Description: "The relative time range of the counted alerts, such as '24 hours', '7 days', 'epoch' or 'login'. Defaults to epoch.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("time_range"),
+			},
+			{
+				Name:        "resource_type",
+				Description: "The resource type of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "remediable",
+				Description: "Indicates if the policy is remediable.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "restrict_alert_dismissal",
+				Description: "Indicates if alert dismissal is restricted for the policy.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "policy_labels",
+				Description: "The labels of the policy.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "mittre_attacks",
+				Description: "The MITRE ATT&CK techniques of the policy.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "finding_types",
+				Description: "The finding types of the policy.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "compliance_metadata",
+				Description: "The compliance metadata associated with the policy.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PolicyName"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudAlertPolicyCounts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_alert_policy_count.listPrismacloudAlertPolicyCounts", "connection_error", err)
+		return nil, err
+	}
+
+	timeRange := interface{}(timerange.Epoch)
+	if d.EqualsQualString("time_range") != "" {
+		timeRange, err = parseRelativeTimeRange(d.EqualsQualString("time_range"))
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_alert_policy_count.listPrismacloudAlertPolicyCounts", "time_range_error", err)
+			return nil, err
+		}
+	}
+	tr := timerange.TimeRange{Value: timeRange}
+	if err := tr.SetType(); err != nil {
+		return nil, err
+	}
+
+	var filters []map[string]string
+	for _, fc := range []struct {
+		column string
+		filter string
+	}{
+		{"alert_status", "alert.status"},
+		{"severity", "policy.severity"},
+		{"cloud_type", "cloud.type"},
+		{"account_group", "cloud.accountGroup"},
+	} {
+		for _, value := range equalsQualStrings(d, fc.column) {
+			filters = append(filters, map[string]string{"name": fc.filter, "operator": "=", "value": value})
+		}
+	}
+
+	err = listAlertCountOfPolicies(ctx, d, conn, filters, tr, func(p model.Policy) bool {
+		d.StreamListItem(ctx, p)
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_alert_policy_count.listPrismacloudAlertPolicyCounts", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// Page through the alert counts of the policies matching the filters, calling fn for each policy until it returns false.
+// https://pan.dev/prisma-cloud/api/cspm/alert-policy-list/
+func listAlertCountOfPolicies(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, filters []map[string]string, timeRange timerange.TimeRange, fn func(model.Policy) bool) error {
+	req := map[string]interface{}{
+		"filters":       append(filters, map[string]string{"name": "timeRange.type", "operator": "=", "value": "ALERT_UPDATED"}),
+		"sortBy":        []string{"alertCount:desc"},
+		"timeRange":     timeRange,
+		"nextPageToken": "",
+	}
+
+	for {
+		if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+			return err
+		}
+
+		results, err := api.GetAlertCountOfPolicies(conn, req)
+		if err != nil {
+			// No policy has alerts matching the filters
+			if strings.Contains(err.Error(), "404") {
+				return nil
+			}
+			return err
+		}
+
+		for _, p := range results.Policies {
+			if !fn(p) {
+				return nil
+			}
+		}
+
+		if results.NextPageToken == "" {
+			return nil
+		}
+		req["nextPageToken"] = results.NextPageToken
+	}
+}
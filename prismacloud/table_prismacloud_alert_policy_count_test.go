@@ -0,0 +1,53 @@
+package prismacloud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListAlertPolicyCounts(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/alert/v1/policy", "alert_policy_counts.json")
+
+	rows := testQuery{
+		table:   "prismacloud_alert_policy_count",
+		columns: []string{"policy_id", "alert_count", "alert_status"},
+		quals: quals(
+			stringQual("alert_status", "=", "open"),
+			stringQual("cloud_type", "=", "aws"),
+			stringQual("account_group", "=", "Production"),
+			stringQual("time_range", "=", "7 days"),
+		),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 policies, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["policy_id"].GetStringValue() == "policy-1" && row["alert_count"].GetIntValue() != 7 {
+			t.Errorf("expected 7 alerts for policy-1, got %v", row["alert_count"])
+		}
+	}
+
+	requests := m.received("POST", "/alert/v1/policy")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 alert count request, got %d", len(requests))
+	}
+	body := requests[0].Body
+
+	filters := map[string]string{}
+	for _, f := range body["filters"].([]interface{}) {
+		f := f.(map[string]interface{})
+		filters[f["name"].(string)] = f["value"].(string)
+	}
+	for name, want := range map[string]string{"alert.status": "open", "cloud.type": "aws", "cloud.accountGroup": "Production"} {
+		if filters[name] != want {
+			t.Errorf("expected %s filter %q, got %v", name, want, filters)
+		}
+	}
+
+	timeRange := body["timeRange"].(map[string]interface{})
+	want := map[string]interface{}{"amount": float64(7), "unit": "day"}
+	if timeRange["type"] != "relative" || !reflect.DeepEqual(timeRange["value"], want) {
+		t.Errorf("expected a relative 7 day time range, got %v", timeRange)
+	}
+}
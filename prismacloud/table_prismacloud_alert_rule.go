@@ -15,6 +15,7 @@ func tablePrismacloudAlertRule(ctx context.Context) *plugin.Table {
 		Description: "List all information for prima cloud alert rules.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudAlertRules,
+			Tags:    serviceTags(serviceAlert),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -46,7 +47,7 @@ func tablePrismacloudAlertRule(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_on",
 				Description: "Timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedOn"),
 			},
 			{
 				Name:        "last_modified_by",
@@ -130,6 +131,24 @@ func tablePrismacloudAlertRule(ctx context.Context) *plugin.Table {
 				Description: "Target configuration.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "account_group_ids",
+				Description: "The IDs of the account groups targeted by the rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Target.AccountGroups"),
+			},
+			{
+				Name:        "excluded_account_ids",
+				Description: "The IDs of the cloud accounts excluded from the rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Target.ExcludedAccounts"),
+			},
+			{
+				Name:        "target_regions",
+				Description: "The regions targeted by the rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Target.Regions"),
+			},
 			{
 				Name:        "policies",
 				Description: "List of policies.",
@@ -155,6 +174,10 @@ func listPrismacloudAlertRules(ctx context.Context, d *plugin.QueryData, _ *plug
 		plugin.Logger(ctx).Error("prismacloud_alert_rule.listPrismacloudAlertRule", "connection_error", err)
 		return nil, err
 	}
+
+	if err := waitForRateLimit(ctx, d, serviceAlert); err != nil {
+		return nil, err
+	}
 	rules, err := rule.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_alert_rule.listPrismacloudAlertRule", "api_error", err)
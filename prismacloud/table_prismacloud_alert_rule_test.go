@@ -0,0 +1,35 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListAlertRulesAccountGroupIds(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/v2/alert/rule", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{
+			"policyScanConfigId": "rule-1",
+			"name": "Production alerts",
+			"enabled": true,
+			"target": {"accountGroups": ["group-1", "group-2"], "excludedAccounts": ["210987654321"], "regions": ["us-east-1"]}
+		}]`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_alert_rule",
+		columns: []string{"name", "account_group_ids", "excluded_account_ids", "target_regions"},
+	}.run(t, m)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 alert rule, got %d", len(rows))
+	}
+	for column, want := range map[string]string{
+		"account_group_ids":    `["group-1","group-2"]`,
+		"excluded_account_ids": `["210987654321"]`,
+		"target_regions":       `["us-east-1"]`,
+	} {
+		if got := string(rows[0][column].GetJsonValue()); got != want {
+			t.Errorf("expected %s %s, got %s", column, want, got)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package prismacloud
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListAlertsPagination(t *testing.T) {
+	m := newMockServer(t)
+	page1 := loadFixture(t, "alerts_page1.json")
+	page2 := loadFixture(t, "alerts_page2.json")
+	m.handle("POST", "/v2/alert", func(r mockRequest) (int, interface{}) {
+		if r.Body["pageToken"] == "page-2" {
+			return http.StatusOK, page2
+		}
+		return http.StatusOK, page1
+	})
+
+	rows := testQuery{table: "prismacloud_alert", columns: []string{"id", "status", "policy_id"}}.run(t, m)
+
+	ids := map[string]bool{}
+	for _, row := range rows {
+		ids[row["id"].GetStringValue()] = true
+	}
+	for _, id := range []string{"P-1001", "P-1002", "P-1003"} {
+		if !ids[id] {
+			t.Errorf("alert %s missing from results: %v", id, ids)
+		}
+	}
+	if got := len(m.received("POST", "/v2/alert")); got != 2 {
+		t.Errorf("expected 2 alert list requests, got %d", got)
+	}
+}
+
+func TestListAlertsLimitStopsPaging(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/v2/alert", "alerts_page1.json")
+
+	rows := testQuery{table: "prismacloud_alert", columns: []string{"id"}, limit: 1}.run(t, m)
+	if len(rows) != 1 {
+		t.Errorf("expected 1 row, got %d", len(rows))
+	}
+
+	requests := m.received("POST", "/v2/alert")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 alert list request, got %d", len(requests))
+	}
+	if limit := requests[0].Body["limit"]; limit != float64(1) {
+		t.Errorf("expected the query limit to be sent as the page size, got %v", limit)
+	}
+}
+
+func TestListAlertsQualPushdown(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id"},
+		quals: quals(
+			stringQual("status", "=", "open"),
+			stringQual("policy_id", "=", "policy-1"),
+			timestampQual("alert_time", ">=", since),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/v2/alert")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 alert list request, got %d", len(requests))
+	}
+	body := requests[0].Body
+
+	filters := map[string]string{}
+	for _, f := range body["filters"].([]interface{}) {
+		f := f.(map[string]interface{})
+		filters[f["name"].(string)] = f["value"].(string)
+	}
+	if filters["alert.status"] != "open" {
+		t.Errorf("expected alert.status filter, got %v", filters)
+	}
+	if filters["policy.id"] != "policy-1" {
+		t.Errorf("expected policy.id filter, got %v", filters)
+	}
+
+	timeRange := body["timeRange"].(map[string]interface{})
+	if timeRange["type"] != "absolute" {
+		t.Errorf("expected an absolute time range, got %v", timeRange["type"])
+	}
+	start := timeRange["value"].(map[string]interface{})["startTime"]
+	if start != float64(since.UnixMilli()) {
+		t.Errorf("expected time range start %d, got %v", since.UnixMilli(), start)
+	}
+}
+
+func TestGetAlert(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/alert/P-1001", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{"id": "P-1001", "status": "resolved"}
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id", "status"},
+		quals:   quals(stringQual("id", "=", "P-1001")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["status"].GetStringValue() != "resolved" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if len(m.received("POST", "/v2/alert")) != 0 {
+		t.Error("expected the get call to be used instead of listing alerts")
+	}
+}
+
+func TestListAlertsFilterListsAndNegation(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+
+	testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id"},
+		quals: quals(
+			stringQual("status", "<>", "open"),
+			stringListQual("policy_severity", "critical", "high"),
+			stringListQual("cloud_account_id", "123456789012", "210987654321"),
+			boolQual("policy_remediable", "<>", true),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/v2/alert")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 alert list request, got %d", len(requests))
+	}
+
+	filters := map[string][]string{}
+	for _, f := range requests[0].Body["filters"].([]interface{}) {
+		f := f.(map[string]interface{})
+		filters[f["name"].(string)] = append(filters[f["name"].(string)], f["value"].(string))
+	}
+	expected := map[string][]string{
+		"alert.status":      {"resolved", "dismissed", "snoozed", "pending_resolution"},
+		"policy.severity":   {"critical", "high"},
+		"cloud.accountId":   {"123456789012", "210987654321"},
+		"policy.remediable": {"false"},
+	}
+	for name, values := range expected {
+		if fmt.Sprint(filters[name]) != fmt.Sprint(values) {
+			t.Errorf("expected %s filters %v, got %v", name, values, filters[name])
+		}
+	}
+}
+
+func TestListAlertsAllValuesExcluded(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+
+	rows := testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id"},
+		quals: quals(
+			stringQual("policy_severity", "=", "high"),
+			stringQual("policy_severity", "<>", "high"),
+		),
+	}.run(t, m)
+	if len(rows) != 0 {
+		t.Errorf("expected no rows, got %d", len(rows))
+	}
+	if n := len(m.received("POST", "/v2/alert")); n != 0 {
+		t.Errorf("expected no alert list request, got %d", n)
+	}
+}
+
+func TestListAlertsTimeRangeBounds(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		quals         []*proto.Qual
+		start, end    int64
+		expectedCalls int
+	}{
+		{
+			name:          "between",
+			quals:         []*proto.Qual{timestampQual("alert_time", ">=", since), timestampQual("alert_time", "<=", until)},
+			start:         since.UnixMilli(),
+			end:           until.UnixMilli(),
+			expectedCalls: 1,
+		},
+		{
+			name:          "strict bounds",
+			quals:         []*proto.Qual{timestampQual("alert_time", ">", since), timestampQual("alert_time", "<", until)},
+			start:         since.UnixMilli() + 1,
+			end:           until.UnixMilli() - 1,
+			expectedCalls: 1,
+		},
+		{
+			name:          "tightest bounds",
+			quals:         []*proto.Qual{timestampQual("alert_time", ">=", since), timestampQual("alert_time", ">=", since.Add(time.Hour)), timestampQual("alert_time", "<", until)},
+			start:         since.Add(time.Hour).UnixMilli(),
+			end:           until.UnixMilli() - 1,
+			expectedCalls: 1,
+		},
+		{
+			name:          "disjoint bounds",
+			quals:         []*proto.Qual{timestampQual("alert_time", ">", until), timestampQual("alert_time", "<", since)},
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockServer(t)
+			m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+
+			testQuery{
+				table:   "prismacloud_alert",
+				columns: []string{"id"},
+				quals:   quals(tt.quals...),
+			}.run(t, m)
+
+			requests := m.received("POST", "/v2/alert")
+			if len(requests) != tt.expectedCalls {
+				t.Fatalf("expected %d alert list requests, got %d", tt.expectedCalls, len(requests))
+			}
+			if tt.expectedCalls == 0 {
+				return
+			}
+
+			timeRange := requests[0].Body["timeRange"].(map[string]interface{})
+			if timeRange["type"] != "absolute" {
+				t.Errorf("expected an absolute time range, got %v", timeRange["type"])
+			}
+			value := timeRange["value"].(map[string]interface{})
+			if value["startTime"] != float64(tt.start) || value["endTime"] != float64(tt.end) {
+				t.Errorf("expected time range %d to %d, got %v to %v", tt.start, tt.end, value["startTime"], value["endTime"])
+			}
+		})
+	}
+}
+
+func TestListAlertsRelativeTimeRange(t *testing.T) {
+	tests := []struct {
+		timeRange string
+		rangeType string
+		value     interface{}
+	}{
+		{"24 hours", "relative", map[string]interface{}{"amount": float64(24), "unit": "hour"}},
+		{"1 Week", "relative", map[string]interface{}{"amount": float64(1), "unit": "week"}},
+		{"epoch", "to_now", "epoch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeRange, func(t *testing.T) {
+			m := newMockServer(t)
+			m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+
+			testQuery{
+				table:   "prismacloud_alert",
+				columns: []string{"id", "time_range"},
+				quals:   quals(stringQual("time_range", "=", tt.timeRange)),
+			}.run(t, m)
+
+			requests := m.received("POST", "/v2/alert")
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 alert list request, got %d", len(requests))
+			}
+			timeRange := requests[0].Body["timeRange"].(map[string]interface{})
+			if timeRange["type"] != tt.rangeType || !reflect.DeepEqual(timeRange["value"], tt.value) {
+				t.Errorf("expected %s time range %v, got %v", tt.rangeType, tt.value, timeRange)
+			}
+		})
+	}
+}
+
+func TestListAlertsInvalidTimeRange(t *testing.T) {
+	m := newMockServer(t)
+	server := newTestPlugin(t, m, "")
+
+	_, err := testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id"},
+		quals:   quals(stringQual("time_range", "=", "yesterday")),
+	}.execute(t, server)
+	if err == nil || !strings.Contains(err.Error(), "invalid time range") {
+		t.Errorf("expected an invalid time range error, got %v", err)
+	}
+	if len(m.received("POST", "/v2/alert")) != 0 {
+		t.Error("expected no alert list request")
+	}
+}
+
+func TestListAlertsResourceConfigAndEvidence(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/v2/alert", "alerts_page2.json")
+	m.handle("POST", "/resource", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"rrn":  r.Body["rrn"],
+			"data": map[string]interface{}{"acl": map[string]interface{}{"grants": []interface{}{"AllUsers"}}},
+		}
+	})
+	m.handle("GET", "/alert/P-1003", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"id": "P-1003",
+			"policy": map[string]interface{}{
+				"policyId": "policy-1",
+				"rule":     map[string]interface{}{"type": "Config", "criteria": "config from cloud.resource where api.name = 'aws-s3api-get-bucket-acl'"},
+			},
+			"alertAdditionalInfo": map[string]interface{}{"aclGrants": "AllUsers"},
+		}
+	})
+	server := newTestPlugin(t, m, "")
+
+	// The hydrated columns are only fetched when selected
+	_, err := testQuery{table: "prismacloud_alert", columns: []string{"id", "resource"}}.execute(t, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.received("POST", "/resource")) != 0 || len(m.received("GET", "/alert/P-1003")) != 0 {
+		t.Fatal("expected no resource or alert info requests when the hydrated columns aren't selected")
+	}
+
+	rows, err := testQuery{
+		table:   "prismacloud_alert",
+		columns: []string{"id", "resource_config", "evidence"},
+	}.execute(t, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	requests := m.received("POST", "/resource")
+	if len(requests) != 1 || requests[0].Body["rrn"] != "rrn::s3:us-east-1:123456789012:bucket-c" {
+		t.Fatalf("expected the resource to be fetched by rrn, got %v", requests)
+	}
+	if config := string(rows[0]["resource_config"].GetJsonValue()); !strings.Contains(config, "AllUsers") {
+		t.Errorf("unexpected resource config: %s", config)
+	}
+
+	evidence := string(rows[0]["evidence"].GetJsonValue())
+	for _, want := range []string{"aws-s3api-get-bucket-acl", `"aclGrants":"AllUsers"`, `"ruleType":"Config"`} {
+		if !strings.Contains(evidence, want) {
+			t.Errorf("expected evidence to contain %s, got %s", want, evidence)
+		}
+	}
+}
+
+func TestListAlertsEvidenceFromDetailedList(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/v2/alert", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`{"items": [{
+			"id": "P-1004",
+			"status": "open",
+			"policy": {"policyId": "policy-1", "rule": {"type": "Config", "criteria": "config from cloud.resource where api.name = 'aws-s3api-get-bucket-acl'"}},
+			"alertAdditionalInfo": {"aclGrants": "AllUsers"}
+		}]}`)
+	})
+
+	rows := testQuery{table: "prismacloud_alert", columns: []string{"id", "evidence"}}.run(t, m)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	evidence := string(rows[0]["evidence"].GetJsonValue())
+	for _, want := range []string{"aws-s3api-get-bucket-acl", `"aclGrants":"AllUsers"`} {
+		if !strings.Contains(evidence, want) {
+			t.Errorf("expected evidence to contain %s, got %s", want, evidence)
+		}
+	}
+	if n := len(m.received("GET", "/alert/P-1004")); n != 0 {
+		t.Errorf("expected the policy rule of the list to be used without fetching the alert, got %d requests", n)
+	}
+}
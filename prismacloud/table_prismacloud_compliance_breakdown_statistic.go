@@ -2,10 +2,7 @@ package prismacloud
 
 import (
 	"context"
-	"net/url"
 
-	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account"
-	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -17,9 +14,9 @@ func tablePrismacloudComplianceBreakdownStatistic(ctx context.Context) *plugin.T
 		Name:        "prismacloud_compliance_breakdown_statistic",
 		Description: "List all available compliance breakdown statistics.",
 		List: &plugin.ListConfig{
-			ParentHydrate: listPrismacloudAccounts,
-			Hydrate:       listPrismacloudComplianceBreakdownStatistics,
-			KeyColumns:    commonComplianceBreakdownKeyQualColumns(),
+			Hydrate:    listPrismacloudComplianceBreakdownStatistics,
+			Tags:       serviceTags(serviceCompliance),
+			KeyColumns: commonComplianceBreakdownKeyQualColumns(),
 		},
 		Columns: commonColumns(complianceBreakdownCommonFilterColumns([]*plugin.Column{
 			{
@@ -110,48 +107,22 @@ type complianceBreakdownStatistic struct {
 
 //// LIST FUNCTION
 
-func listPrismacloudComplianceBreakdownStatistics(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+func listPrismacloudComplianceBreakdownStatistics(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	err := listComplianceBreakdowns(ctx, d, func(scope complianceBreakdownScope, postures *model.ComplianceData) bool {
+		for _, posture := range postures.ComplianceDetails {
+			d.StreamListItem(ctx, complianceBreakdownStatistic{scope.AccountName, scope.AccountId, scope.CloudType, posture})
 
-	account := h.Item.(account.Account)
-
-	if d.EqualsQualString("account_name") != "" && d.EqualsQualString("account_name") != account.Name {
-		return nil, nil
-	}
-
-	if d.EqualsQualString("cloud_type") != "" && d.EqualsQualString("cloud_type") != account.CloudType {
-		return nil, nil
-	}
-	conn, err := connect(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("prismacloud_compliance_breakdown_statistic.listPrismacloudComplianceBreakdownStatistics", "connection_error", err)
-		return nil, err
-	}
-
-	// For any of the query parameter it the returning the same row. However, the query param is required to make the the API call do hardcoded the value, and added it as a optional qual.
-	// If we are not specifying any of the parameter the API doesn't return any result.
-	query := url.Values{
-		"cloud.account": []string{account.Name},
-	}
-
-	query = buildComplianceBreakdownStatisticQueryParameter(ctx, d, query)
-
-	postures, err := api.LisComplianceBreakdownStatistics(conn, query)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return false
+			}
+		}
+		return true
+	})
 	if err != nil {
-
 		plugin.Logger(ctx).Error("prismacloud_compliance_breakdown_statistic.listPrismacloudComplianceBreakdownStatistics", "api_error", err)
 		return nil, err
 	}
 
-	for _, posture := range postures.ComplianceDetails {
-
-		d.StreamListItem(ctx, complianceBreakdownStatistic{account.Name, account.AccountId, account.CloudType, posture})
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
-
-	}
-
 	return nil, nil
 }
@@ -2,15 +2,10 @@ package prismacloud
 
 import (
 	"context"
-	"net/url"
 
-	"github.com/paloaltonetworks/prisma-cloud-go/cloud/account"
-	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
 )
 
 func tablePrismacloudComplianceBreakdownSummary(ctx context.Context) *plugin.Table {
@@ -18,16 +13,9 @@ func tablePrismacloudComplianceBreakdownSummary(ctx context.Context) *plugin.Tab
 		Name:        "prismacloud_compliance_breakdown_summary",
 		Description: "List all available compliance breakdown summary.",
 		List: &plugin.ListConfig{
-			ParentHydrate: listPrismacloudAccounts,
-			Hydrate:       listPrismacloudComplianceBreakdownSummary,
-			KeyColumns: plugin.KeyColumnSlice{
-				{Name: "account_name", Require: plugin.Optional},
-				{Name: "cloud_type", Require: plugin.Optional},
-				{Name: "cloud_region", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
-				{Name: "policy_compliance_standard_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
-				{Name: "policy_compliance_requirement_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
-				{Name: "policy_compliance_section_id", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
-			},
+			Hydrate:    listPrismacloudComplianceBreakdownSummary,
+			Tags:       serviceTags(serviceCompliance),
+			KeyColumns: commonComplianceBreakdownKeyQualColumns(),
 		},
 		Columns: commonColumns(complianceBreakdownCommonFilterColumns([]*plugin.Column{
 			{
@@ -69,7 +57,7 @@ func tablePrismacloudComplianceBreakdownSummary(ctx context.Context) *plugin.Tab
 				Name:        "timestamp",
 				Description: "Timestamp of the compliance summary.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("Timestamp").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("Timestamp"),
 			},
 			{
 				Name:        "total_resources",
@@ -89,47 +77,17 @@ type complianceBreakdownSummary struct {
 
 //// LIST FUNCTION
 
-func listPrismacloudComplianceBreakdownSummary(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	account := h.Item.(account.Account)
+func listPrismacloudComplianceBreakdownSummary(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	err := listComplianceBreakdowns(ctx, d, func(scope complianceBreakdownScope, postures *model.ComplianceData) bool {
+		d.StreamListItem(ctx, complianceBreakdownSummary{scope.AccountName, scope.AccountId, scope.CloudType, postures.Summary})
 
-	conn, err := connect(ctx, d)
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
 	if err != nil {
-		plugin.Logger(ctx).Error("prismacloud_compliance_breakdown_summary.listPrismacloudComplianceBreakdownSummary", "connection_error", err)
-		return nil, err
-	}
-
-	if d.EqualsQualString("account_name") != "" && d.EqualsQualString("account_name") != account.Name {
-		return nil, nil
-	}
-
-	if d.EqualsQualString("cloud_type") != "" && d.EqualsQualString("cloud_type") != account.CloudType {
-		return nil, nil
-	}
-
-	// For any of the query parameter it the returning the same row. However, the query param is required to make the the API call do hardcoded the value, and added it as a optional qual.
-	// If we are not specifying any of the parameter the API doesn't return any result.
-	query := url.Values{
-		"cloud.account": []string{account.Name},
-	}
-
-	if d.EqualsQualString("policy_compliance_standard_name") != "" {
-		query["policy.complianceStandard"] = []string{d.EqualsQualString("policy_compliance_standard_name")}
-	}
-	if d.EqualsQualString("policy_compliance_requirement_name") != "" {
-		query["policy.complianceRequirement"] = []string{d.EqualsQualString("policy_compliance_requirement_name")}
-	}
-	if d.EqualsQualString("policy_compliance_section_id") != "" {
-		query["policy.complianceSection"] = []string{d.EqualsQualString("policy_compliance_section_id")}
-	}
-
-	postures, err := api.LisComplianceBreakdownStatistics(conn, query)
-	if err != nil {
-
 		plugin.Logger(ctx).Error("prismacloud_compliance_breakdown_summary.listPrismacloudComplianceBreakdownSummary", "api_error", err)
 		return nil, err
 	}
 
-	d.StreamListItem(ctx, complianceBreakdownSummary{account.Name, account.AccountId, account.CloudType, postures.Summary})
-
 	return nil, nil
 }
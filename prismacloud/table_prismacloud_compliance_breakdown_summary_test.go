@@ -0,0 +1,121 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testCompliancePosture = `{
+  "summary": {"passedResources": 80, "failedResources": 20, "totalResources": 100},
+  "complianceDetails": [{"id": "std-1", "name": "CIS AWS", "passedResources": 40, "failedResources": 10, "totalResources": 50}],
+  "requirementSummaries": [{"id": "req-1", "name": "Identity", "sectionSummaries": [{"id": "1.1", "passedResources": 4, "failedResources": 1, "totalResources": 5}]}]
+}`
+
+func handleCompliancePosture(m *mockServer) {
+	m.handle("GET", "/v2/compliance/posture", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testCompliancePosture)
+	})
+}
+
+func TestListComplianceBreakdownSummarySingleCall(t *testing.T) {
+	m := newMockServer(t)
+	handleCompliancePosture(m)
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_breakdown_summary",
+		columns: []string{"cloud_type", "account_group", "passed_resources", "total_resources"},
+		quals:   quals(stringQual("account_group", "=", "Production")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["passed_resources"].GetIntValue() != 80 || rows[0]["account_group"].GetStringValue() != "Production" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+
+	// A tenant wide summary doesn't need the accounts
+	if got := len(m.received("GET", "/cloud")); got != 0 {
+		t.Errorf("expected no account request, got %d", got)
+	}
+	requests := m.received("GET", "/v2/compliance/posture")
+	if len(requests) != 1 || requests[0].Query.Get("account.group") != "Production" || requests[0].Query.Get("cloud.account") != "" {
+		t.Errorf("unexpected posture requests: %v", requests)
+	}
+}
+
+func TestListComplianceBreakdownSummaryPerCloudType(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/cloud", "cloud_accounts.json")
+	handleCompliancePosture(m)
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_breakdown_summary",
+		columns: []string{"cloud_type", "passed_resources"},
+	}.run(t, m)
+
+	var cloudTypes []string
+	for _, row := range rows {
+		cloudTypes = append(cloudTypes, row["cloud_type"].GetStringValue())
+	}
+	sort.Strings(cloudTypes)
+
+	var requested []string
+	for _, r := range m.received("GET", "/v2/compliance/posture") {
+		if r.Query.Get("cloud.account") != "" {
+			t.Errorf("expected no account parameter, got %v", r.Query)
+		}
+		requested = append(requested, r.Query.Get("cloud.type"))
+	}
+	sort.Strings(requested)
+
+	// One call per cloud type rather than per account
+	if strings.Join(cloudTypes, ",") != "aws,gcp" || strings.Join(requested, ",") != "aws,gcp" {
+		t.Errorf("unexpected cloud types: rows %v, requests %v", cloudTypes, requested)
+	}
+}
+
+func TestListComplianceBreakdownStatisticPerAccount(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/cloud", "cloud_accounts.json")
+	handleCompliancePosture(m)
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_breakdown_statistic",
+		columns: []string{"account_name", "account_id", "id", "passed_resources"},
+		quals:   quals(stringQual("policy_compliance_standard_name", "=", "CIS AWS")),
+	}.run(t, m)
+
+	var accounts []string
+	for _, row := range rows {
+		accounts = append(accounts, row["account_name"].GetStringValue()+"/"+row["account_id"].GetStringValue())
+	}
+	sort.Strings(accounts)
+	if strings.Join(accounts, ",") != "aws-prod/123456789012,gcp-dev/my-gcp-project" {
+		t.Errorf("unexpected accounts: %v", accounts)
+	}
+
+	for _, r := range m.received("GET", "/v2/compliance/posture") {
+		if r.Query.Get("cloud.account") == "" || r.Query.Get("policy.complianceStandard") != "CIS AWS" {
+			t.Errorf("unexpected posture request: %v", r.Query)
+		}
+	}
+}
+
+func TestListComplianceBreakdownRequirementSummaryByAccountName(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/cloud", "cloud_accounts.json")
+	handleCompliancePosture(m)
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_breakdown_requirement_summary",
+		columns: []string{"account_name", "account_id", "id", "name"},
+		quals:   quals(stringQual("account_name", "=", "gcp-dev")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["account_id"].GetStringValue() != "my-gcp-project" || rows[0]["name"].GetStringValue() != "Identity" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	requests := m.received("GET", "/v2/compliance/posture")
+	if len(requests) != 1 || requests[0].Query.Get("cloud.account") != "gcp-dev" {
+		t.Errorf("unexpected posture requests: %v", requests)
+	}
+}
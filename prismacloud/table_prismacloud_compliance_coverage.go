@@ -0,0 +1,221 @@
+package prismacloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/policy"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudComplianceCoverage(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_compliance_coverage",
+		Description: "List the sections of the compliance standards, along with the number of policies covering them.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listPrismacloudComplianceStandards,
+			ParentTags:    serviceTags(serviceCompliance),
+			Hydrate:       listPrismacloudComplianceCoverages,
+			Tags:          serviceTags(serviceCompliance),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "standard_id", Require: plugin.Optional},
+				{Name: "standard_name", Require: plugin.Optional},
+				{Name: "requirement_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "section_id",
+				Description: "The identifier of the section within the requirement, such as 1.1.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SectionID"),
+			},
+			{
+				Name:        "id",
+				Description: "The unique identifier for the section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "requirement_id",
+				Description: "The unique identifier for the requirement of the section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RequirementID"),
+			},
+			{
+				Name:        "requirement_name",
+				Description: "The name of the requirement of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "standard_id",
+				Description: "The unique identifier for the compliance standard of the section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("StandardID"),
+			},
+			{
+				Name:        "standard_name",
+				Description: "The name of the compliance standard of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "label",
+				Description: "The label of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "view_order",
+				Description: "The order in which the section should be viewed.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "covered",
+				Description: "Indicates if at least one enabled policy with a severity other than informational is mapped to the section.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "mapped_policy_count",
+				Description: "The number of policies mapped to the section.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "enabled_policy_count",
+				Description: "The number of enabled policies mapped to the section.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "remediable_policy_count",
+				Description: "The number of remediable policies mapped to the section.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "mapped_policy_ids",
+				Description: "The IDs of the policies mapped to the section.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the compliance section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(complianceCoverageTitle),
+			},
+		}),
+	}
+}
+
+// ComplianceCoverage is a section of a compliance requirement, along with the policies mapped to it
+type ComplianceCoverage struct {
+	ComplianceSection
+	Covered               bool
+	MappedPolicyCount     int
+	EnabledPolicyCount    int
+	RemediablePolicyCount int
+	MappedPolicyIds       []string
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudComplianceCoverages(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	standard := h.Item.(*model.ComplianceStandard)
+
+	// Restrict API calls with given standard ID or name
+	if d.EqualsQualString("standard_id") != "" && d.EqualsQualString("standard_id") != standard.ID {
+		return nil, nil
+	}
+	if d.EqualsQualString("standard_name") != "" && d.EqualsQualString("standard_name") != standard.Name {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_coverage.listPrismacloudComplianceCoverages", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
+	policies, err := policy.List(conn, map[string]string{"policy.complianceStandard": standard.Name})
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_coverage.listPrismacloudComplianceCoverages", "api_error", err)
+		return nil, err
+	}
+
+	policiesById := make(map[string]policy.Policy, len(policies))
+	sectionPolicyIds := map[string]map[string]bool{}
+	for _, p := range policies {
+		policiesById[p.PolicyId] = p
+		for _, metadata := range p.ComplianceMetadata {
+			if metadata.StandardName != standard.Name || metadata.ComplianceId == "" {
+				continue
+			}
+			if sectionPolicyIds[metadata.ComplianceId] == nil {
+				sectionPolicyIds[metadata.ComplianceId] = map[string]bool{}
+			}
+			sectionPolicyIds[metadata.ComplianceId][p.PolicyId] = true
+		}
+	}
+
+	_, err = listComplianceStandardSections(ctx, d, conn, standard, func(section ComplianceSection) bool {
+		policyIds := sectionPolicyIds[section.ID]
+		if policyIds == nil {
+			policyIds = map[string]bool{}
+		}
+		for _, id := range section.AssociatedPolicyIDs {
+			policyIds[id] = true
+		}
+
+		coverage := ComplianceCoverage{ComplianceSection: section, MappedPolicyIds: []string{}}
+		for id := range policyIds {
+			coverage.MappedPolicyIds = append(coverage.MappedPolicyIds, id)
+
+			// Policies assigned to the section but not listed for the standard can't be inspected
+			p, ok := policiesById[id]
+			if !ok {
+				continue
+			}
+			if p.Enabled {
+				coverage.EnabledPolicyCount++
+				if !strings.EqualFold(p.Severity, "informational") {
+					coverage.Covered = true
+				}
+			}
+			if p.Remediable {
+				coverage.RemediablePolicyCount++
+			}
+		}
+		sort.Strings(coverage.MappedPolicyIds)
+		coverage.MappedPolicyCount = len(coverage.MappedPolicyIds)
+
+		d.StreamListItem(ctx, coverage)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_coverage.listPrismacloudComplianceCoverages", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func complianceCoverageTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	c := d.HydrateItem.(ComplianceCoverage)
+	return fmt.Sprintf("%s %s", c.StandardName, c.SectionID), nil
+}
@@ -0,0 +1,63 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const testCoveragePolicies = `[
+  {"policyId": "policy-1", "name": "Root account has MFA", "severity": "high", "enabled": true, "remediable": true, "complianceMetadata": [
+    {"standardName": "CIS AWS", "sectionId": "1.1", "complianceId": "sec-1"}
+  ]},
+  {"policyId": "policy-2", "name": "IAM user has a password", "severity": "informational", "enabled": true, "complianceMetadata": [
+    {"standardName": "CIS AWS", "sectionId": "1.1", "complianceId": "sec-1"},
+    {"standardName": "CIS AWS", "sectionId": "1.2", "complianceId": "sec-2"}
+  ]},
+  {"policyId": "policy-3", "name": "CloudTrail is disabled", "severity": "medium", "enabled": false, "remediable": true, "complianceMetadata": [
+    {"standardName": "CIS AWS", "sectionId": "2.1", "complianceId": "sec-3"}
+  ]}
+]`
+
+func TestListComplianceCoverages(t *testing.T) {
+	m := newMockServer(t)
+	handleComplianceSections(m)
+	m.handle("GET", "/v2/policy", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testCoveragePolicies)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_coverage",
+		columns: []string{"id", "section_id", "standard_name", "covered", "mapped_policy_count", "enabled_policy_count", "remediable_policy_count"},
+		quals:   quals(stringQual("standard_name", "=", "CIS AWS")),
+	}.run(t, m)
+
+	var coverages []string
+	for _, row := range rows {
+		coverages = append(coverages, strings.Join([]string{
+			row["id"].GetStringValue(),
+			row["section_id"].GetStringValue(),
+			row["standard_name"].GetStringValue(),
+			strconv.FormatBool(row["covered"].GetBoolValue()),
+			strconv.FormatInt(row["mapped_policy_count"].GetIntValue(), 10),
+			strconv.FormatInt(row["enabled_policy_count"].GetIntValue(), 10),
+			strconv.FormatInt(row["remediable_policy_count"].GetIntValue(), 10),
+		}, " "))
+	}
+	sort.Strings(coverages)
+	expected := []string{
+		"sec-1 1.1 CIS AWS true 2 2 1",
+		"sec-2 1.2 CIS AWS false 1 1 0",
+		"sec-3 2.1 CIS AWS false 1 0 1",
+	}
+	if strings.Join(coverages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected coverages:\n%s", strings.Join(coverages, "\n"))
+	}
+
+	requests := m.received("GET", "/v2/policy")
+	if len(requests) != 1 || requests[0].Query.Get("policy.complianceStandard") != "CIS AWS" {
+		t.Errorf("expected a single policy list for CIS AWS, got %v", requests)
+	}
+}
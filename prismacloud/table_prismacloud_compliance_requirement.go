@@ -16,15 +16,21 @@ func tablePrismacloudComplianceRequirement(ctx context.Context) *plugin.Table {
 		Description: "List all available compliance requirement.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudComplianceRequirement,
+			Tags:       serviceTags(serviceCompliance),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		List: &plugin.ListConfig{
 			ParentHydrate: listPrismacloudComplianceStandards,
+			ParentTags:    serviceTags(serviceCompliance),
 			Hydrate:       listPrismacloudComplianceRequirements,
+			Tags:          serviceTags(serviceCompliance),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "compliance_id", Require: plugin.Optional},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getPrismacloudComplianceRequirementSections, Tags: serviceTags(serviceCompliance)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -52,7 +58,7 @@ func tablePrismacloudComplianceRequirement(ctx context.Context) *plugin.Table {
 				Name:        "created_on",
 				Description: "The timestamp when the requirement was created.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("CreatedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("CreatedOn"),
 			},
 			{
 				Name:        "description",
@@ -68,7 +74,7 @@ func tablePrismacloudComplianceRequirement(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_on",
 				Description: "The timestamp when the requirement was last modified.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedOn"),
 			},
 			{
 				Name:        "policies_assigned_count",
@@ -133,6 +139,10 @@ func listPrismacloudComplianceRequirements(ctx context.Context, d *plugin.QueryD
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return nil, err
+	}
+
 	requirements, err := api.ListComplianceRequirements(conn, complianceId)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_compliance_requirement.listPrismacloudComplianceRequirements", "api_error", err)
@@ -169,6 +179,10 @@ func getPrismacloudComplianceRequirement(ctx context.Context, d *plugin.QueryDat
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return nil, err
+	}
+
 	requirement, err := api.GetComplianceRequirement(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_compliance_requirement.getPrismacloudComplianceRequirement", "api_error", err)
@@ -191,6 +205,10 @@ func getPrismacloudComplianceRequirementSections(ctx context.Context, d *plugin.
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return nil, err
+	}
+
 	requirementSessions, err := api.ListComplianceRequirementSections(conn, requirement.ID)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_compliance_requirement.getPrismacloudComplianceRequirement", "api_error", err)
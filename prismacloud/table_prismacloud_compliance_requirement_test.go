@@ -0,0 +1,68 @@
+package prismacloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestListComplianceRequirementsByComplianceId(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/compliance", "compliance_standards.json")
+	m.handleFixture("GET", "/compliance/std-1/requirement", "compliance_requirements.json")
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_requirement",
+		columns: []string{"id", "name", "compliance_id", "requirement_id", "view_order"},
+		quals:   quals(stringQual("compliance_id", "=", "std-1")),
+	}.run(t, m)
+
+	var requirements []string
+	for _, row := range rows {
+		requirements = append(requirements, strings.Join([]string{
+			row["compliance_id"].GetStringValue(),
+			row["requirement_id"].GetStringValue(),
+			row["id"].GetStringValue(),
+			row["name"].GetStringValue(),
+		}, " "))
+	}
+	sort.Strings(requirements)
+	expected := []string{"std-1 1 req-1 Identity and Access Management", "std-1 2 req-2 Storage"}
+	if strings.Join(requirements, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requirements:\n%s", strings.Join(requirements, "\n"))
+	}
+
+	// The requirements of the other standards aren't listed
+	if n := len(m.received("GET", "/compliance/std-2/requirement")); n != 0 {
+		t.Errorf("expected the requirements of std-2 not to be listed, got %d requests", n)
+	}
+}
+
+func TestGetComplianceRequirementWithSections(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/compliance/requirement/req-1", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`{"id": "req-1", "complianceId": "std-1", "requirementId": "1", "name": "Identity and Access Management"}`)
+	})
+	m.handle("GET", "/compliance/req-1/section", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "sec-1", "sectionId": "1.1"}, {"id": "sec-2", "sectionId": "1.2"}]`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_requirement",
+		columns: []string{"id", "name", "requirement_sections"},
+		quals:   quals(stringQual("id", "=", "req-1")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["name"].GetStringValue() != "Identity and Access Management" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+
+	var sections []map[string]interface{}
+	if err := json.Unmarshal(rows[0]["requirement_sections"].GetJsonValue(), &sections); err != nil {
+		t.Fatal(err)
+	}
+	if len(sections) != 2 || sections[0]["sectionId"] != "1.1" {
+		t.Errorf("unexpected requirement sections: %v", sections)
+	}
+}
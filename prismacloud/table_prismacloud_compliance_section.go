@@ -0,0 +1,261 @@
+package prismacloud
+
+import (
+	"context"
+
+	prismacloud "github.com/paloaltonetworks/prisma-cloud-go"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudComplianceSection(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_compliance_section",
+		Description: "List the sections of the compliance requirements, along with their policies.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudComplianceSections,
+			Tags:    serviceTags(serviceCompliance),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "standard_id", Require: plugin.Optional},
+				{Name: "requirement_id", Require: plugin.Optional},
+			},
+			// A requirement_id of an unknown requirement returns no rows
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"object not found"}),
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "section_id",
+				Description: "The identifier of the section within the requirement, such as 1.1.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SectionID"),
+			},
+			{
+				Name:        "id",
+				Description: "The unique identifier for the section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "requirement_id",
+				Description: "The unique identifier for the requirement of the section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RequirementID"),
+			},
+			{
+				Name:        "requirement_name",
+				Description: "The name of the requirement of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "standard_id",
+				Description: "The unique identifier for the compliance standard of the section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("StandardID"),
+			},
+			{
+				Name:        "standard_name",
+				Description: "The name of the compliance standard of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "label",
+				Description: "The label of the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "view_order",
+				Description: "The order in which the section should be viewed.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "policies_assigned_count",
+				Description: "The number of policies assigned to the section.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "associated_policy_ids",
+				Description: "The IDs of the policies assigned to the section.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AssociatedPolicyIDs"),
+			},
+			{
+				Name:        "system_default",
+				Description: "Indicates if the section is a system default.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "created_by",
+				Description: "The user who created the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "created_on",
+				Description: "The timestamp when the section was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("CreatedOn"),
+			},
+			{
+				Name:        "last_modified_by",
+				Description: "The user who last modified the section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_modified_on",
+				Description: "The timestamp when the section was last modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("LastModifiedOn"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the compliance section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SectionID"),
+			},
+		}),
+	}
+}
+
+// ComplianceSection is a section of a compliance requirement, along with the standard it belongs to
+type ComplianceSection struct {
+	StandardID string
+	model.ComplianceRequirementSection
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudComplianceSections(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_section.listPrismacloudComplianceSections", "connection_error", err)
+		return nil, err
+	}
+
+	err = listComplianceSections(ctx, d, conn, func(section ComplianceSection) bool {
+		d.StreamListItem(ctx, section)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_section.listPrismacloudComplianceSections", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// List the sections of the compliance requirements, calling fn for each section until it returns false.
+// With a requirement_id qual, only that requirement is fetched instead of the requirements of every standard.
+func listComplianceSections(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, fn func(ComplianceSection) bool) error {
+	standardId := d.EqualsQualString("standard_id")
+
+	if requirementId := d.EqualsQualString("requirement_id"); requirementId != "" {
+		if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+			return err
+		}
+
+		requirement, err := api.GetComplianceRequirement(conn, requirementId)
+		if err != nil {
+			return err
+		}
+		if standardId != "" && standardId != requirement.ComplianceID {
+			return nil
+		}
+
+		standard := &model.ComplianceStandard{ID: requirement.ComplianceID, Name: requirement.StandardName}
+		_, err = listComplianceRequirementSections(ctx, d, conn, standard, requirement, fn)
+		return err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return err
+	}
+
+	standards, err := api.ListComplianceStandards(conn)
+	if err != nil {
+		return err
+	}
+
+	for _, standard := range standards {
+		// Restrict API calls with given standard ID
+		if standardId != "" && standardId != standard.ID {
+			continue
+		}
+
+		more, err := listComplianceStandardSections(ctx, d, conn, standard, fn)
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List the sections of the requirements of a standard, returning false once fn does.
+// Only the sections of the requirement given by the requirement_id qual are listed when it is set.
+func listComplianceStandardSections(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, standard *model.ComplianceStandard, fn func(ComplianceSection) bool) (bool, error) {
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return false, err
+	}
+
+	requirements, err := api.ListComplianceRequirements(conn, standard.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, requirement := range requirements {
+		// Restrict API calls with given requirement ID
+		if d.EqualsQualString("requirement_id") != "" && d.EqualsQualString("requirement_id") != requirement.ID {
+			continue
+		}
+
+		more, err := listComplianceRequirementSections(ctx, d, conn, standard, requirement, fn)
+		if err != nil || !more {
+			return more, err
+		}
+	}
+
+	return true, nil
+}
+
+// List the sections of a requirement, returning false once fn does
+func listComplianceRequirementSections(ctx context.Context, d *plugin.QueryData, conn *prismacloud.Client, standard *model.ComplianceStandard, requirement *model.ComplianceRequirement, fn func(ComplianceSection) bool) (bool, error) {
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return false, err
+	}
+
+	sections, err := api.ListComplianceRequirementSections(conn, requirement.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, section := range sections {
+		// Use the ID of prismacloud_compliance_requirement so the tables can be joined
+		section.RequirementID = requirement.ID
+		if section.RequirementName == "" {
+			section.RequirementName = requirement.Name
+		}
+		if section.StandardName == "" {
+			section.StandardName = standard.Name
+		}
+		if !fn(ComplianceSection{StandardID: standard.ID, ComplianceRequirementSection: *section}) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,125 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func handleComplianceSections(m *mockServer) {
+	m.handle("GET", "/compliance", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "std-1", "name": "CIS AWS"}, {"id": "std-2", "name": "PCI DSS"}]`)
+	})
+	m.handle("GET", "/compliance/std-1/requirement", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "req-1", "name": "Identity", "requirementId": "1"}, {"id": "req-2", "name": "Logging", "requirementId": "2"}]`)
+	})
+	m.handle("GET", "/compliance/std-2/requirement", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "req-3", "name": "Network", "requirementId": "1"}]`)
+	})
+	m.handle("GET", "/compliance/req-1/section", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "sec-1", "sectionId": "1.1", "requirementId": "1", "associatedPolicyIds": ["policy-1", "policy-2"]}, {"id": "sec-2", "sectionId": "1.2", "requirementId": "1"}]`)
+	})
+	m.handle("GET", "/compliance/req-2/section", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "sec-3", "sectionId": "2.1", "requirementId": "2"}]`)
+	})
+	m.handle("GET", "/compliance/req-3/section", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "sec-4", "sectionId": "1.1", "requirementId": "1"}]`)
+	})
+}
+
+func TestListComplianceSections(t *testing.T) {
+	m := newMockServer(t)
+	handleComplianceSections(m)
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_section",
+		columns: []string{"id", "section_id", "requirement_id", "requirement_name", "standard_id", "standard_name", "associated_policy_ids"},
+	}.run(t, m)
+
+	var sections []string
+	for _, row := range rows {
+		sections = append(sections, strings.Join([]string{
+			row["standard_id"].GetStringValue(),
+			row["standard_name"].GetStringValue(),
+			row["requirement_id"].GetStringValue(),
+			row["requirement_name"].GetStringValue(),
+			row["section_id"].GetStringValue(),
+		}, " "))
+		if row["id"].GetStringValue() == "sec-1" {
+			if got := string(row["associated_policy_ids"].GetJsonValue()); got != `["policy-1","policy-2"]` {
+				t.Errorf("unexpected associated policy ids: %s", got)
+			}
+		}
+	}
+	sort.Strings(sections)
+	expected := []string{
+		"std-1 CIS AWS req-1 Identity 1.1",
+		"std-1 CIS AWS req-1 Identity 1.2",
+		"std-1 CIS AWS req-2 Logging 2.1",
+		"std-2 PCI DSS req-3 Network 1.1",
+	}
+	if strings.Join(sections, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected sections:\n%s", strings.Join(sections, "\n"))
+	}
+}
+
+func TestListComplianceSectionsByRequirement(t *testing.T) {
+	m := newMockServer(t)
+	handleComplianceSections(m)
+	m.handle("GET", "/compliance/requirement/req-2", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`{"id": "req-2", "name": "Logging", "requirementId": "2", "complianceId": "std-1", "standardName": "CIS AWS"}`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_section",
+		columns: []string{"id", "requirement_id", "requirement_name", "standard_id", "standard_name"},
+		quals:   quals(stringQual("requirement_id", "=", "req-2")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["id"].GetStringValue() != "sec-3" || rows[0]["requirement_name"].GetStringValue() != "Logging" ||
+		rows[0]["standard_id"].GetStringValue() != "std-1" || rows[0]["standard_name"].GetStringValue() != "CIS AWS" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	// Only the requirement and its sections are queried
+	for _, path := range []string{"/compliance", "/compliance/std-1/requirement", "/compliance/std-2/requirement", "/compliance/req-1/section"} {
+		if got := len(m.received("GET", path)); got != 0 {
+			t.Errorf("expected no request to %s, got %d", path, got)
+		}
+	}
+}
+
+func TestListComplianceSectionsByRequirementOfAnotherStandard(t *testing.T) {
+	m := newMockServer(t)
+	handleComplianceSections(m)
+	m.handle("GET", "/compliance/requirement/req-2", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`{"id": "req-2", "name": "Logging", "requirementId": "2", "complianceId": "std-1", "standardName": "CIS AWS"}`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_section",
+		columns: []string{"id"},
+		quals:   quals(stringQual("standard_id", "=", "std-2"), stringQual("requirement_id", "=", "req-2")),
+	}.run(t, m)
+	if len(rows) != 0 {
+		t.Errorf("expected no rows, got %v", rows)
+	}
+	if got := len(m.received("GET", "/compliance/req-2/section")); got != 0 {
+		t.Errorf("expected no section request, got %d", got)
+	}
+}
+
+func TestListComplianceSectionsByUnknownRequirement(t *testing.T) {
+	m := newMockServer(t)
+	handleComplianceSections(m)
+	m.handleError("GET", "/compliance/requirement/missing", http.StatusBadRequest, "not_found")
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_section",
+		columns: []string{"id"},
+		quals:   quals(stringQual("requirement_id", "=", "missing")),
+	}.run(t, m)
+	if len(rows) != 0 {
+		t.Errorf("expected no rows, got %v", rows)
+	}
+}
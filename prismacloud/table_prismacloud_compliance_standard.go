@@ -15,10 +15,12 @@ func tablePrismacloudComplianceStandard(ctx context.Context) *plugin.Table {
 		Description: "List all available compliance standard.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudComplianceStandard,
+			Tags:       serviceTags(serviceCompliance),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudComplianceStandards,
+			Tags:    serviceTags(serviceCompliance),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -46,7 +48,7 @@ func tablePrismacloudComplianceStandard(ctx context.Context) *plugin.Table {
 				Name:        "created_on",
 				Description: "The timestamp when the standard was created.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("CreatedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("CreatedOn"),
 			},
 			{
 				Name:        "created_by",
@@ -67,7 +69,7 @@ func tablePrismacloudComplianceStandard(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_on",
 				Description: "The timestamp when the standard was last modified.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedOn"),
 			},
 			{
 				Name:        "cloud_type",
@@ -95,6 +97,10 @@ func listPrismacloudComplianceStandards(ctx context.Context, d *plugin.QueryData
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return nil, err
+	}
+
 	standards, err := api.ListComplianceStandards(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_compliance_standard.listPrismacloudComplianceStandards", "api_error", err)
@@ -131,6 +137,10 @@ func getPrismacloudComplianceStandard(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return nil, err
+	}
+
 	standard, err := api.GetComplianceStandard(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_compliance_standard.getPrismacloudComplianceStandard", "api_error", err)
@@ -0,0 +1,55 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestListComplianceStandards(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/compliance", "compliance_standards.json")
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_standard",
+		columns: []string{"id", "name", "cloud_type", "system_default", "created_on"},
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 standards, got %d", len(rows))
+	}
+
+	var standards []string
+	for _, row := range rows {
+		standards = append(standards, row["id"].GetStringValue()+" "+row["name"].GetStringValue()+" "+string(row["cloud_type"].GetJsonValue()))
+		if row["id"].GetStringValue() == "std-1" {
+			if !row["system_default"].GetBoolValue() || row["created_on"].GetTimestampValue().AsTime().UnixMilli() != 1672531200000 {
+				t.Errorf("unexpected standard std-1: %v", row)
+			}
+		}
+	}
+	sort.Strings(standards)
+	expected := []string{`std-1 CIS v1.5.0 (AWS) ["aws"]`, `std-2 Custom GCP baseline ["gcp"]`}
+	if strings.Join(standards, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected standards:\n%s", strings.Join(standards, "\n"))
+	}
+}
+
+func TestGetComplianceStandard(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/compliance/std-2", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`{"id": "std-2", "name": "Custom GCP baseline", "cloudType": ["gcp"], "policiesAssignedCount": 4}`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_standard",
+		columns: []string{"id", "name", "policies_assigned_count"},
+		quals:   quals(stringQual("id", "=", "std-2")),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["name"].GetStringValue() != "Custom GCP baseline" || rows[0]["policies_assigned_count"].GetIntValue() != 4 {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if n := len(m.received("GET", "/compliance")); n != 0 {
+		t.Errorf("expected the standard to be fetched without listing the standards, got %d list requests", n)
+	}
+}
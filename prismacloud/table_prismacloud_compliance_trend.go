@@ -0,0 +1,209 @@
+package prismacloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
+)
+
+func tablePrismacloudComplianceTrend(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_compliance_trend",
+		Description: "List the compliance posture of the resources over time.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudComplianceTrend,
+			Tags:    serviceTags(serviceCompliance),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "standard_id", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "requirement_id", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "account_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "account_group", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "cloud_type", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "cloud_region", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "time_range", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+				{Name: "timestamp", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "timestamp",
+				Description: "The time of the compliance posture.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("Timestamp"),
+			},
+			{
+				Name:        "passed_resources",
+				Description: "The number of resources passing all their policies.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "failed_resources",
+				Description: "The number of resources failing a policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "total_resources",
+				Description: "The number of resources checked.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "critical_severity_failed_resources",
+				Description: "The number of resources failing a critical severity policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "high_severity_failed_resources",
+				Description: "The number of resources failing a high severity policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "medium_severity_failed_resources",
+				Description: "The number of resources failing a medium severity policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "low_severity_failed_resources",
+				Description: "The number of resources failing a low severity policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "informational_severity_failed_resources",
+				Description: "The number of resources failing an informational severity policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "standard_id",
+				Description: "The unique identifier for the compliance standard of the trend. Null for the trend of all the standards.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("standard_id"),
+			},
+			{
+				Name:        "requirement_id",
+				Description: "The unique identifier for the compliance requirement of the trend. Requires standard_id.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("requirement_id"),
+			},
+			{
+				Name:        "account_name",
+				Description: "The name of the cloud account of the trend.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("account_name"),
+			},
+			{
+				Name:        "account_group",
+				Description: "The name of the account group of the trend.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("account_group"),
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The type of cloud of the trend (e.g., AWS, Azure, GCP).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("cloud_type"),
+			},
+			{
+				Name:        "cloud_region",
+				Description: "The cloud region of the trend.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("cloud_region"),
+			},
+			{
+				Name:        "time_range",
+				Description: "The relative time range of the trend, such as '30 days', '6 months', 'epoch' or 'login'. Defaults to epoch.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("time_range"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudComplianceTrend(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	standardId := d.EqualsQualString("standard_id")
+	requirementId := d.EqualsQualString("requirement_id")
+	if requirementId != "" && standardId == "" {
+		return nil, fmt.Errorf("the trend of a requirement_id also requires its standard_id")
+	}
+
+	timeRange, err := timeRangeValueFromQuals(d, "timestamp")
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_trend.listPrismacloudComplianceTrend", "time_range_error", err)
+		return nil, err
+	}
+	// The timestamp quals don't overlap
+	if timeRange == nil {
+		return nil, nil
+	}
+
+	queryParameter := timeRangeQueryParameter(timeRange)
+	for _, fc := range []struct {
+		column string
+		param  string
+	}{
+		{"account_name", "cloud.account"},
+		{"account_group", "account.group"},
+		{"cloud_type", "cloud.type"},
+		{"cloud_region", "cloud.region"},
+	} {
+		if value := d.EqualsQualString(fc.column); value != "" {
+			queryParameter.Set(fc.param, value)
+		}
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_trend.listPrismacloudComplianceTrend", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+		return nil, err
+	}
+
+	trend, err := api.ListComplianceTrend(conn, standardId, requirementId, queryParameter)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_compliance_trend.listPrismacloudComplianceTrend", "api_error", err)
+		return nil, err
+	}
+
+	for _, item := range trend {
+		d.StreamListItem(ctx, item)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// Returns the query parameters of a time range, for the GET endpoints which don't take a timerange.TimeRange body
+func timeRangeQueryParameter(value interface{}) url.Values {
+	queryParameter := make(url.Values)
+	switch v := value.(type) {
+	case timerange.Absolute:
+		queryParameter.Set("timeType", timerange.TypeAbsolute)
+		queryParameter.Set("startTime", strconv.Itoa(v.Start))
+		queryParameter.Set("endTime", strconv.Itoa(v.End))
+	case timerange.Relative:
+		queryParameter.Set("timeType", timerange.TypeRelative)
+		queryParameter.Set("timeAmount", strconv.Itoa(v.Amount))
+		queryParameter.Set("timeUnit", v.Unit)
+	case string:
+		queryParameter.Set("timeType", timerange.TypeToNow)
+		queryParameter.Set("timeUnit", v)
+	}
+	return queryParameter
+}
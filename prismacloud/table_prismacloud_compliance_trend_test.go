@@ -0,0 +1,91 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testComplianceTrend = `[
+  {"timestamp": 1714521600000, "passedResources": 80, "failedResources": 20, "totalResources": 100, "highSeverityFailedResources": 5},
+  {"timestamp": 1714608000000, "passedResources": 85, "failedResources": 15, "totalResources": 100, "highSeverityFailedResources": 3}
+]`
+
+func TestListComplianceTrend(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/v2/compliance/posture/trend/std-1/req-1", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testComplianceTrend)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_compliance_trend",
+		columns: []string{"timestamp", "passed_resources", "failed_resources", "standard_id", "requirement_id", "account_name"},
+		quals: quals(
+			stringQual("standard_id", "=", "std-1"),
+			stringQual("requirement_id", "=", "req-1"),
+			stringQual("account_name", "=", "aws-prod"),
+			stringQual("time_range", "=", "30 days"),
+		),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 trend points, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["standard_id"].GetStringValue() != "std-1" || row["requirement_id"].GetStringValue() != "req-1" || row["account_name"].GetStringValue() != "aws-prod" {
+			t.Errorf("unexpected qual columns: %v", row)
+		}
+		if row["timestamp"].GetTimestampValue().AsTime().UnixMilli() == 1714608000000 && row["passed_resources"].GetIntValue() != 85 {
+			t.Errorf("unexpected passed resources: %v", row["passed_resources"])
+		}
+	}
+
+	requests := m.received("GET", "/v2/compliance/posture/trend/std-1/req-1")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 trend request, got %d", len(requests))
+	}
+	q := requests[0].Query
+	if q.Get("timeType") != "relative" || q.Get("timeAmount") != "30" || q.Get("timeUnit") != "day" || q.Get("cloud.account") != "aws-prod" {
+		t.Errorf("unexpected query parameters: %v", q)
+	}
+}
+
+func TestListComplianceTrendAbsoluteTimeRange(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/v2/compliance/posture/trend", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testComplianceTrend)
+	})
+
+	start := time.UnixMilli(1714521600000)
+	end := time.UnixMilli(1714608000000)
+	testQuery{
+		table:   "prismacloud_compliance_trend",
+		columns: []string{"timestamp", "total_resources"},
+		quals: quals(
+			timestampQual("timestamp", ">=", start),
+			timestampQual("timestamp", "<=", end),
+		),
+	}.run(t, m)
+
+	requests := m.received("GET", "/v2/compliance/posture/trend")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 trend request, got %d", len(requests))
+	}
+	q := requests[0].Query
+	if q.Get("timeType") != "absolute" || q.Get("startTime") != "1714521600000" || q.Get("endTime") != "1714608000000" {
+		t.Errorf("unexpected query parameters: %v", q)
+	}
+}
+
+func TestListComplianceTrendRequirementWithoutStandard(t *testing.T) {
+	m := newMockServer(t)
+	server := newTestPlugin(t, m, "")
+
+	_, err := testQuery{
+		table:   "prismacloud_compliance_trend",
+		columns: []string{"timestamp"},
+		quals:   quals(stringQual("requirement_id", "=", "req-1")),
+	}.execute(t, server)
+	if err == nil {
+		t.Fatal("expected an error for a requirement_id without a standard_id")
+	}
+}
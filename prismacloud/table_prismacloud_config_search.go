@@ -0,0 +1,226 @@
+package prismacloud
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudConfigSearch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_config_search",
+		Description: "Search cloud resource configurations with an RQL config query.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudConfigSearch,
+			Tags:    serviceTags(serviceSearch),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "query", Require: plugin.Required},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "query",
+				Description: "The RQL config query, for example config from cloud.resource where cloud.type = 'aws'.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rrn",
+				Description: "The Prisma Cloud resource RRN.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The cloud resource ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_id",
+				Description: "The cloud account ID of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_name",
+				Description: "The cloud account name of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The cloud type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "region_id",
+				Description: "The region ID of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "region_name",
+				Description: "The region name of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service",
+				Description: "The cloud service of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state_id",
+				Description: "The ID of the resource snapshot.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "insert_ts",
+				Description: "The time the resource snapshot was ingested.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("InsertTs"),
+			},
+			{
+				Name:        "deleted",
+				Description: "Indicates if the resource has been deleted.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "has_alert",
+				Description: "Indicates if the resource has alerts.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "has_network",
+				Description: "Indicates if the resource has network data.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "data",
+				Description: "The raw JSON configuration of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "search_id",
+				Description: "The unique identifier of the search.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "time_range",
+				Description: "The time range of the search.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard column
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+type ConfigSearchResult struct {
+	Query     string
+	SearchId  string
+	TimeRange map[string]interface{}
+	model.ConfigSearchItem
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudConfigSearch(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	query := d.EqualsQualString("query")
+
+	// Empty check
+	if query == "" {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_config_search.listPrismacloudConfigSearch", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+		return nil, err
+	}
+
+	maxLimit := int32(10000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	// Search the latest configuration snapshot of the resources
+	req := map[string]interface{}{
+		"query":            query,
+		"limit":            maxLimit,
+		"withResourceJson": true,
+		"timeRange": map[string]interface{}{
+			"type":  "to_now",
+			"value": "epoch",
+		},
+	}
+
+	resp, err := api.ConfigSearch(conn, req)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_config_search.listPrismacloudConfigSearch", "api_error", err)
+		return nil, err
+	}
+
+	for _, item := range resp.Data.Items {
+
+		d.StreamListItem(ctx, ConfigSearchResult{query, resp.Id, resp.TimeRange, item})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+
+	}
+
+	pageToken := resp.Data.NextPageToken
+	for pageToken != "" {
+		if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+			return nil, err
+		}
+
+		page, err := api.ConfigSearchPage(conn, map[string]interface{}{
+			"limit":            maxLimit,
+			"pageToken":        pageToken,
+			"withResourceJson": true,
+		})
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_config_search.listPrismacloudConfigSearch", "api_paging_error", err)
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+
+			d.StreamListItem(ctx, ConfigSearchResult{query, resp.Id, resp.TimeRange, item})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+
+		}
+		pageToken = page.NextPageToken
+	}
+
+	return nil, nil
+}
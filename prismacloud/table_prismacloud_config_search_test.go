@@ -0,0 +1,38 @@
+package prismacloud
+
+import (
+	"testing"
+)
+
+func TestListConfigSearchPagination(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search/config", "config_search.json")
+	m.handleFixture("POST", "/search/config/page", "config_search_page2.json")
+
+	rql := "config from cloud.resource where api.name = 'aws-s3api-get-bucket-acl'"
+	rows := testQuery{
+		table:   "prismacloud_config_search",
+		columns: []string{"query", "rrn", "id", "account_id", "data", "search_id"},
+		quals:   quals(stringQual("query", "=", rql)),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["query"].GetStringValue() != rql || row["search_id"].GetStringValue() != "search-config-1" {
+			t.Errorf("expected the search metadata on every row, got %v", row)
+		}
+		if row["data"].GetJsonValue() == nil {
+			t.Errorf("expected the resource JSON, got %v", row["data"])
+		}
+	}
+
+	searches := m.received("POST", "/search/config")
+	if len(searches) != 1 || searches[0].Body["query"] != rql || searches[0].Body["withResourceJson"] != true {
+		t.Errorf("unexpected config search requests: %v", searches)
+	}
+	pages := m.received("POST", "/search/config/page")
+	if len(pages) != 1 || pages[0].Body["pageToken"] != "config-page-2" {
+		t.Errorf("unexpected config search page requests: %v", pages)
+	}
+}
@@ -0,0 +1,267 @@
+package prismacloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Events searched when no event_time quals are given
+const defaultEventSearchWindow = 24 * time.Hour
+
+func tablePrismacloudEventSearch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_event_search",
+		Description: "Search cloud audit events with an RQL event query.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudEventSearch,
+			Tags:    serviceTags(serviceSearch),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "query", Require: plugin.Required},
+				{Name: "event_time", Require: plugin.Optional, Operators: []string{"=", ">=", "<=", ">", "<"}},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "query",
+				Description: "The RQL event query, for example event from cloud.audit_logs where operation = 'DeleteBucket'.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The unique identifier of the event.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "event_time",
+				Description: "The time the event occurred.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("EventTs"),
+			},
+			{
+				Name:        "ingestion_time",
+				Description: "The time the event was ingested by Prisma Cloud.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("IngestionTs"),
+			},
+			{
+				Name:        "subject",
+				Description: "The user or identity which performed the operation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subject_type",
+				Description: "The type of the subject.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "operation",
+				Description: "The operation performed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "source",
+				Description: "The cloud service which emitted the event.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of the event.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_ip",
+				Description: "The IP address the operation was performed from.",
+				Type:        proto.ColumnType_INET,
+				Transform:   fromIpField("Ip"),
+			},
+			{
+				Name:        "account_id",
+				Description: "The cloud account ID of the event.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Account"),
+			},
+			{
+				Name:        "account_name",
+				Description: "The cloud account name of the event.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "region_id",
+				Description: "The region ID of the event.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RegionApiIdentifier"),
+			},
+			{
+				Name:        "region_name",
+				Description: "The region name of the event.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "success",
+				Description: "Indicates if the operation succeeded.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "internal",
+				Description: "Indicates if the operation was performed by the cloud provider.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "access_key_used",
+				Description: "Indicates if an access key was used to perform the operation.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "role",
+				Description: "The role assumed to perform the operation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "location",
+				Description: "The location the operation was performed from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anomaly_id",
+				Description: "The ID of the anomaly associated with the event.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "data",
+				Description: "The raw JSON of the event.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Raw"),
+			},
+			{
+				Name:        "search_id",
+				Description: "The unique identifier of the search.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "time_range",
+				Description: "The time range of the search.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard column
+			{
+				Name:        "title",
+				Description: "The title of the event.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+type EventSearchResult struct {
+	Query     string
+	SearchId  string
+	TimeRange map[string]interface{}
+	model.EventSearchItem
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudEventSearch(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	query := d.EqualsQualString("query")
+
+	// Empty check
+	if query == "" {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_event_search.listPrismacloudEventSearch", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+		return nil, err
+	}
+
+	maxLimit := int32(10000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	// Push the event_time quals into the search time range
+	// With only an upper bound the search starts at the epoch
+	st, et := timeRangeFromQuals(d, "event_time")
+	if st == 0 && et == 0 {
+		st = time.Now().Add(-defaultEventSearchWindow).UnixMilli()
+	}
+	if et == 0 {
+		et = time.Now().UnixMilli()
+	}
+	if st > et {
+		return nil, nil
+	}
+
+	req := map[string]interface{}{
+		"query":            query,
+		"limit":            maxLimit,
+		"withResourceJson": true,
+		"timeRange": map[string]interface{}{
+			"type": "absolute",
+			"value": map[string]int64{
+				"startTime": st,
+				"endTime":   et,
+			},
+		},
+	}
+
+	resp, err := api.EventSearch(conn, req)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_event_search.listPrismacloudEventSearch", "api_error", err)
+		return nil, err
+	}
+
+	for _, item := range resp.Data.Items {
+
+		d.StreamListItem(ctx, EventSearchResult{query, resp.Id, resp.TimeRange, item})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+
+	}
+
+	for resp.Data.NextPageToken != "" {
+		if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+			return nil, err
+		}
+
+		req["nextPageToken"] = resp.Data.NextPageToken
+		resp, err = api.EventSearch(conn, req)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_event_search.listPrismacloudEventSearch", "api_paging_error", err)
+			return nil, err
+		}
+
+		for _, item := range resp.Data.Items {
+
+			d.StreamListItem(ctx, EventSearchResult{query, resp.Id, resp.TimeRange, item})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+
+		}
+	}
+
+	return nil, nil
+}
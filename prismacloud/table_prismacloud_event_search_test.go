@@ -0,0 +1,102 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListEventSearchTimeRange(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search/event", "event_search.json")
+
+	rql := "event from cloud.audit_logs where operation = 'DeleteBucket'"
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	rows := testQuery{
+		table:   "prismacloud_event_search",
+		columns: []string{"query", "subject", "operation", "source_ip", "data"},
+		quals: quals(
+			stringQual("query", "=", rql),
+			timestampQual("event_time", ">=", from),
+			timestampQual("event_time", "<", to),
+		),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["operation"].GetStringValue() != "DeleteBucket" || rows[0]["source_ip"].GetCidrRangeValue() != "203.0.113.10" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+	if data := string(rows[0]["data"].GetJsonValue()); data == "" || data == "null" {
+		t.Errorf("expected the raw event JSON, got %q", data)
+	}
+
+	requests := m.received("POST", "/search/event")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 event search request, got %d", len(requests))
+	}
+	timeRange := requests[0].Body["timeRange"].(map[string]interface{})
+	value := timeRange["value"].(map[string]interface{})
+	if timeRange["type"] != "absolute" || value["startTime"] != float64(from.UnixMilli()) || value["endTime"] != float64(to.UnixMilli()-1) {
+		t.Errorf("expected the event_time quals in the time range, got %v", timeRange)
+	}
+}
+
+func TestListEventSearchUpperBoundOnly(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search/event", "event_search.json")
+
+	to := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	testQuery{
+		table:   "prismacloud_event_search",
+		columns: []string{"subject"},
+		quals: quals(
+			stringQual("query", "=", "event from cloud.audit_logs where operation = 'DeleteBucket'"),
+			timestampQual("event_time", "<=", to),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/search/event")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 event search request, got %d", len(requests))
+	}
+	value := requests[0].Body["timeRange"].(map[string]interface{})["value"].(map[string]interface{})
+	if value["startTime"] != float64(0) || value["endTime"] != float64(to.UnixMilli()) {
+		t.Errorf("expected the search to start at the epoch and end at the event_time bound, got %v", value)
+	}
+}
+
+func TestListEventSearchPagination(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("POST", "/search/event", func(r mockRequest) (int, interface{}) {
+		if r.Body["nextPageToken"] == "page-2" {
+			return http.StatusOK, []byte(`{"id": "search-event-1", "data": {"nextPageToken": "", "items": [{"id": 9002, "subject": "bob"}]}}`)
+		}
+		return http.StatusOK, []byte(`{"id": "search-event-1", "data": {"nextPageToken": "page-2", "items": [{"id": 9001, "subject": "alice"}]}}`)
+	})
+
+	rql := "event from cloud.audit_logs where operation = 'DeleteBucket'"
+	rows := testQuery{
+		table:   "prismacloud_event_search",
+		columns: []string{"subject"},
+		quals:   quals(stringQual("query", "=", rql)),
+	}.run(t, m)
+
+	var subjects []string
+	for _, row := range rows {
+		subjects = append(subjects, row["subject"].GetStringValue())
+	}
+	sort.Strings(subjects)
+	if strings.Join(subjects, ",") != "alice,bob" {
+		t.Errorf("expected the events of both pages, got %v", subjects)
+	}
+
+	// The next page is requested by posting the same search again with the page token
+	requests := m.received("POST", "/search/event")
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 event search requests, got %d", len(requests))
+	}
+	if requests[1].Body["nextPageToken"] != "page-2" || requests[1].Body["query"] != rql || requests[1].Body["timeRange"] == nil {
+		t.Errorf("expected the search to be posted again with the page token, got %v", requests[1].Body)
+	}
+}
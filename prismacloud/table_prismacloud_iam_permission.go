@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
@@ -17,10 +18,9 @@ func tablePrismacloudIAMPermission(ctx context.Context) *plugin.Table {
 		Name:        "prismacloud_iam_permission",
 		Description: "List all available permission for the accounts.",
 		List: &plugin.ListConfig{
-			Hydrate: listPrismacloudIAMPermissions,
-			KeyColumns: plugin.KeyColumnSlice{
-				{Name: "permission_query", Require: plugin.Optional},
-			},
+			Hydrate:    listPrismacloudIAMPermissions,
+			Tags:       serviceTags(serviceIAMSearch),
+			KeyColumns: iamPermissionKeyColumns(),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -227,7 +227,8 @@ func tablePrismacloudIAMPermission(ctx context.Context) *plugin.Table {
 			{
 				Name:        "last_access_date",
 				Description: "The date of last access.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromTimeStringField("LastAccessDate"),
 			},
 			{
 				Name:        "last_access_status",
@@ -329,6 +330,10 @@ func listPrismacloudIAMPermissions(ctx context.Context, d *plugin.QueryData, _ *
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceIAMSearch); err != nil {
+		return nil, err
+	}
+
 	maxLimit := int32(10000)
 	if d.QueryContext.Limit != nil {
 		limit := int32(*d.QueryContext.Limit)
@@ -343,23 +348,14 @@ func listPrismacloudIAMPermissions(ctx context.Context, d *plugin.QueryData, _ *
 
 	// https://docs.prismacloud.io/en/classic/rql-reference/rql-reference/iam-query/iam-query-examples#id565e9de4-815d-4794-a3c3-7aecb6d9fb91
 	req := map[string]interface{}{
-		"query": "config from iam where dest.cloud.resource.name = '*'", // Default to all permissions
-		"groupByFields": []string{"source",
-			"sourceCloudAccount",
-			"grantedByEntity",
-			"entityCloudAccount",
-			"grantedByPolicy",
-			"policyCloudAccount",
-			"grantedByLevel",
-			"action",
-			"destination",
-			"destCloudAccount",
-			"lastAccess"},
+		"query":         "config from iam where dest.cloud.resource.name = '*'", // Default to all permissions
+		"groupByFields": iamPermissionGroupByFields,
 	}
 
-	if d.EqualsQualString("permission_query") != "" {
-		req["query"] = d.EqualsQualString("permission_query")
+	if rql := buildIAMPermissionQuery(d); rql != "" {
+		req["query"] = rql
 	}
+	plugin.Logger(ctx).Debug("prismacloud_iam_permission.listPrismacloudIAMPermissions", "query", req["query"])
 
 	resp, err := api.ListIAMPermissions(conn, query, req)
 	if err != nil {
@@ -367,6 +363,12 @@ func listPrismacloudIAMPermissions(ctx context.Context, d *plugin.QueryData, _ *
 		return nil, err
 	}
 
+	// Rows must match the permission_query qual, not the query combined with the other quals
+	permissionQuery := d.EqualsQualString("permission_query")
+	if permissionQuery != "" {
+		resp.Query = permissionQuery
+	}
+
 	for _, perm := range resp.Data.Items {
 
 		d.StreamListItem(ctx, IAMPerm{resp.Query, resp.Id, resp.Saved, resp.Name, resp.TimeRange, resp.SearchType, resp.Description, resp.CloudType, perm})
@@ -385,6 +387,9 @@ func listPrismacloudIAMPermissions(ctx context.Context, d *plugin.QueryData, _ *
 			plugin.Logger(ctx).Error("prismacloud_iam_permission.listPrismacloudIAMPermissions", "api_paging_error", err)
 			return nil, err
 		}
+		if permissionQuery != "" {
+			resp.Query = permissionQuery
+		}
 
 		for _, perm := range resp.Data.Items {
 
@@ -400,3 +405,101 @@ func listPrismacloudIAMPermissions(ctx context.Context, d *plugin.QueryData, _ *
 
 	return nil, nil
 }
+
+//// UTILITY FUNCTION
+
+// Fields the permissions are grouped by, one item is returned per unique combination
+var iamPermissionGroupByFields = []string{
+	"source",
+	"sourceCloudAccount",
+	"grantedByEntity",
+	"entityCloudAccount",
+	"grantedByPolicy",
+	"policyCloudAccount",
+	"grantedByLevel",
+	"action",
+	"destination",
+	"destCloudAccount",
+	"lastAccess",
+}
+
+// IAM RQL attributes for the columns which can be pushed down into the permission query
+// https://docs.prismacloud.io/en/classic/rql-reference/rql-reference/iam-query/iam-query-attributes
+var iamPermissionQualAttributes = []struct {
+	column    string
+	attribute string
+}{
+	{"source_cloud_type", "source.cloud.type"},
+	{"source_cloud_account", "source.cloud.account"},
+	{"source_cloud_region", "source.cloud.region"},
+	{"source_cloud_service_name", "source.cloud.service.name"},
+	{"source_resource_name", "source.cloud.resource.name"},
+	{"source_resource_type", "source.cloud.resource.type"},
+	{"source_resource_id", "source.cloud.resource.id"},
+	{"source_idp_service", "source.idp.service"},
+	{"source_idp_domain", "source.idp.domain"},
+	{"source_idp_email", "source.idp.email"},
+	{"source_idp_username", "source.idp.username"},
+	{"source_idp_group", "source.idp.group"},
+	{"dest_cloud_type", "dest.cloud.type"},
+	{"dest_cloud_account", "dest.cloud.account"},
+	{"dest_cloud_region", "dest.cloud.region"},
+	{"dest_cloud_service_name", "dest.cloud.service.name"},
+	{"dest_resource_name", "dest.cloud.resource.name"},
+	{"dest_resource_type", "dest.cloud.resource.type"},
+	{"dest_resource_id", "dest.cloud.resource.id"},
+	{"effective_action_name", "action.name"},
+	{"granted_by_cloud_type", "grantedby.cloud.type"},
+	{"granted_by_cloud_policy_id", "grantedby.cloud.policy.id"},
+	{"granted_by_cloud_policy_name", "grantedby.cloud.policy.name"},
+	{"granted_by_cloud_policy_type", "grantedby.cloud.policy.type"},
+	{"granted_by_cloud_entity_id", "grantedby.cloud.entity.id"},
+	{"granted_by_cloud_entity_name", "grantedby.cloud.entity.name"},
+	{"granted_by_cloud_entity_type", "grantedby.cloud.entity.type"},
+	{"granted_by_level_type", "grantedby.level.type"},
+	{"granted_by_level_id", "grantedby.level.id"},
+	{"granted_by_level_name", "grantedby.level.name"},
+}
+
+func iamPermissionKeyColumns() plugin.KeyColumnSlice {
+	keyColumns := plugin.KeyColumnSlice{
+		{Name: "permission_query", Require: plugin.Optional},
+		{Name: "source_public", Require: plugin.Optional, Operators: []string{"="}},
+	}
+	for _, qa := range iamPermissionQualAttributes {
+		keyColumns = append(keyColumns, &plugin.KeyColumn{Name: qa.column, Require: plugin.Optional, Operators: []string{"="}})
+	}
+	return keyColumns
+}
+
+// Build the IAM RQL query from the permission_query and the column quals.
+// Returns an empty string when there is nothing to push down.
+func buildIAMPermissionQuery(d *plugin.QueryData) string {
+	var conditions []string
+
+	if d.EqualsQuals["source_public"] != nil {
+		conditions = append(conditions, fmt.Sprintf("source.public = %t", d.EqualsQuals["source_public"].GetBoolValue()))
+	}
+
+	for _, qa := range iamPermissionQualAttributes {
+		if values := equalsQualStrings(d, qa.column); len(values) > 0 {
+			conditions = append(conditions, rqlCondition(qa.attribute, values))
+		}
+	}
+
+	query := strings.TrimSpace(d.EqualsQualString("permission_query"))
+	if len(conditions) == 0 {
+		return query
+	}
+
+	where := strings.Join(conditions, " and ")
+	if query == "" {
+		return "config from iam where " + where
+	}
+	if i := strings.Index(strings.ToLower(query), " where "); i >= 0 {
+		// Parenthesize the where-clause of the query so an or in it doesn't bypass the quals
+		clause := strings.TrimSpace(query[i+len(" where "):])
+		return query[:i] + " where (" + clause + ") and " + where
+	}
+	return query + " where " + where
+}
@@ -0,0 +1,127 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListIAMPermissionsPagination(t *testing.T) {
+	m := newMockServer(t)
+	page1 := loadFixture(t, "iam_permissions_page1.json")
+	page2 := loadFixture(t, "iam_permissions_page2.json")
+	m.handle("POST", "/iam/api/v4/search/permission", func(r mockRequest) (int, interface{}) {
+		if r.Body["nextPageToken"] == "token-2" {
+			return http.StatusOK, page2
+		}
+		return http.StatusOK, page1
+	})
+
+	rows := testQuery{table: "prismacloud_iam_permission", columns: []string{"id", "response_id", "permission_query"}}.run(t, m)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["response_id"].GetStringValue() != "search-1" {
+			t.Errorf("expected the search id on every row, got %v", row["response_id"])
+		}
+	}
+}
+
+func TestListIAMPermissionsQuery(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/iam/api/v4/search/permission", "iam_permissions_page2.json")
+
+	rql := "config from iam where source.cloud.service.name = 'ec2'"
+	testQuery{
+		table:   "prismacloud_iam_permission",
+		columns: []string{"id"},
+		quals:   quals(stringQual("permission_query", "=", rql)),
+	}.run(t, m)
+
+	requests := m.received("POST", "/iam/api/v4/search/permission")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 permission search request, got %d", len(requests))
+	}
+	if got := requests[0].Body["query"]; got != rql {
+		t.Errorf("expected query %q, got %v", rql, got)
+	}
+}
+
+func TestListIAMPermissionsQualPushdown(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/iam/api/v4/search/permission", "iam_permissions_page2.json")
+
+	testQuery{
+		table:   "prismacloud_iam_permission",
+		columns: []string{"id"},
+		quals: quals(
+			stringQual("source_cloud_account", "=", "bob's account"),
+			stringListQual("dest_cloud_service_name", "s3", "ec2"),
+			stringListQual("effective_action_name", "s3:GetObject", "ec2:DescribeInstances"),
+			boolQual("source_public", "=", false),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/iam/api/v4/search/permission")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 permission search request, got %d", len(requests))
+	}
+	// With more than one IN list the values are passed through instead of listing once per value
+	want := `config from iam where source.public = false and source.cloud.account = 'bob\'s account' and dest.cloud.service.name IN ('s3', 'ec2') and action.name IN ('s3:GetObject', 'ec2:DescribeInstances')`
+	if got := requests[0].Body["query"]; got != want {
+		t.Errorf("expected query %q, got %v", want, got)
+	}
+}
+
+func TestListIAMPermissionsQueryWithQuals(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/iam/api/v4/search/permission", "iam_permissions_page2.json")
+
+	rql := "config from iam where source.cloud.service.name = 'ec2'"
+	rows := testQuery{
+		table:   "prismacloud_iam_permission",
+		columns: []string{"id", "permission_query"},
+		quals: quals(
+			stringQual("permission_query", "=", rql),
+			stringQual("effective_action_name", "=", "ec2:DescribeInstances"),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/iam/api/v4/search/permission")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 permission search request, got %d", len(requests))
+	}
+	want := "config from iam where (source.cloud.service.name = 'ec2') and action.name = 'ec2:DescribeInstances'"
+	if got := requests[0].Body["query"]; got != want {
+		t.Errorf("expected query %q, got %v", want, got)
+	}
+	for _, row := range rows {
+		if row["permission_query"].GetStringValue() != rql {
+			t.Errorf("expected permission_query to match the qual, got %v", row["permission_query"])
+		}
+	}
+}
+
+func TestListIAMPermissionsQueryWithOrAndQuals(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/iam/api/v4/search/permission", "iam_permissions_page2.json")
+
+	rql := "config from iam where source.cloud.service.name = 'ec2' or source.cloud.service.name = 's3'"
+	testQuery{
+		table:   "prismacloud_iam_permission",
+		columns: []string{"id", "permission_query"},
+		quals: quals(
+			stringQual("permission_query", "=", rql),
+			stringQual("dest_cloud_account", "=", "123456789012"),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/iam/api/v4/search/permission")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 permission search request, got %d", len(requests))
+	}
+	want := "config from iam where (source.cloud.service.name = 'ec2' or source.cloud.service.name = 's3') and dest.cloud.account = '123456789012'"
+	if got := requests[0].Body["query"]; got != want {
+		t.Errorf("expected query %q, got %v", want, got)
+	}
+}
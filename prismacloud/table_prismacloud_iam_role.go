@@ -15,6 +15,7 @@ func tablePrismacloudIAMRole(ctx context.Context) *plugin.Table {
 		Description: "List all available roles for the users.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudIAMRoles,
+			Tags:    serviceTags(serviceSettings),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -46,7 +47,7 @@ func tablePrismacloudIAMRole(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_ts",
 				Description: "The timestamp when the role was last modified.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedTs").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedTs"),
 			},
 			{
 				Name:        "restrict_dismissal_access",
@@ -104,6 +105,10 @@ func listPrismacloudIAMRoles(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	roles, err := role.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_user_role.listPrismacloudIAMRoles", "api_error", err)
@@ -15,6 +15,7 @@ func tablePrismacloudIAMUser(ctx context.Context) *plugin.Table {
 		Description: "List all available users and service accounts.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudIAMUsers,
+			Tags:    serviceTags(serviceSettings),
 		},
 		Columns: []*plugin.Column{
 			{
@@ -61,7 +62,7 @@ func tablePrismacloudIAMUser(ctx context.Context) *plugin.Table {
 				Name:        "access_key_expiration",
 				Description: "Expiration time of the access key.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("AccessKeyExpiration").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("AccessKeyExpiration"),
 			},
 			{
 				Name:        "access_key_name",
@@ -96,7 +97,7 @@ func tablePrismacloudIAMUser(ctx context.Context) *plugin.Table {
 				Name:        "last_login_ts",
 				Description: "Timestamp of the last login.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastLoginTs").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastLoginTs"),
 			},
 			{
 				Name:        "last_modified_by",
@@ -108,7 +109,7 @@ func tablePrismacloudIAMUser(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_ts",
 				Description: "Timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedTs").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedTs"),
 			},
 			{
 				Name:        "access_keys_count",
@@ -148,6 +149,10 @@ func listPrismacloudIAMUsers(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	users, err := profile.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_iam_user.listPrismacloudIAMUsers", "api_error", err)
@@ -16,6 +16,7 @@ func tablePrismacloudInventoryAPIEndpoint(ctx context.Context) *plugin.Table {
 		Description: "Query Prisma Cloud inventory API endpoint.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudInventoryAPIEndpoints,
+			Tags:    serviceTags(serviceInventory),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -88,13 +89,13 @@ func tablePrismacloudInventoryAPIEndpoint(ctx context.Context) *plugin.Table {
 				Name:        "last_changed",
 				Description: "The timestamp when the asset was last changed.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastChanged").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastChanged"),
 			},
 			{
 				Name:        "last_observed",
 				Description: "The timestamp when the asset was last observed.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastObserved").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastObserved"),
 			},
 			{
 				Name:        "total",
@@ -138,6 +139,10 @@ func listPrismacloudInventoryAPIEndpoints(ctx context.Context, d *plugin.QueryDa
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
 	maxLimit := int32(100)
 	if d.QueryContext.Limit != nil {
 		limit := int32(*d.QueryContext.Limit)
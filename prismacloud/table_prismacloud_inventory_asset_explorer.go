@@ -18,6 +18,7 @@ func tablePrismacloudInventoryAssetExplorer(ctx context.Context) *plugin.Table {
 		Description: "Prisma Cloud inventory asset explorer.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudInventoryAssetExplorer,
+			Tags:    serviceTags(serviceInventory),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "account_name", Require: plugin.Optional},
 				{Name: "cloud_type", Require: plugin.Optional},
@@ -199,6 +200,10 @@ func listPrismacloudInventoryAssetExplorer(ctx context.Context, d *plugin.QueryD
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
 	maxLimit := int32(10000)
 	if d.QueryContext.Limit != nil {
 		limit := int32(*d.QueryContext.Limit)
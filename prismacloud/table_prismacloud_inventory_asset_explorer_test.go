@@ -0,0 +1,57 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListInventoryAssetExplorerPagination(t *testing.T) {
+	m := newMockServer(t)
+	page1, page2 := loadFixture(t, "asset_explorer_page1.json"), loadFixture(t, "asset_explorer_page2.json")
+	m.handle("GET", "/v2/resource/scan_info", func(r mockRequest) (int, interface{}) {
+		if r.Query.Get("pageToken") == "page-2" {
+			return http.StatusOK, page2
+		}
+		return http.StatusOK, page1
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_inventory_asset_explorer",
+		columns: []string{"id", "account_name", "cloud_type", "alert_status_high", "vulnerability_status_critical", "scan_status"},
+		quals: quals(
+			stringQual("account_name", "=", "aws-prod"),
+			stringQual("cloud_type", "=", "aws"),
+			stringQual("scan_status", "=", "failed"),
+		),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 resources across both pages, got %d", len(rows))
+	}
+	for _, row := range rows {
+		switch row["id"].GetStringValue() {
+		case "bucket-a":
+			if row["alert_status_high"].GetIntValue() != 2 {
+				t.Errorf("unexpected alert status for bucket-a: %v", row)
+			}
+		case "i-0abc":
+			if row["vulnerability_status_critical"].GetIntValue() != 1 {
+				t.Errorf("unexpected vulnerability status for i-0abc: %v", row)
+			}
+		default:
+			t.Errorf("unexpected resource %v", row["id"])
+		}
+		if row["scan_status"].GetStringValue() != "failed" {
+			t.Errorf("expected scan_status to be set from the qual, got %v", row["scan_status"])
+		}
+	}
+
+	requests := m.received("GET", "/v2/resource/scan_info")
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 page requests, got %d", len(requests))
+	}
+	for param, want := range map[string]string{"cloud.account": "aws-prod", "cloud.type": "aws", "scan.status": "failed"} {
+		if got := requests[0].Query.Get(param); got != want {
+			t.Errorf("expected %s=%s, got %q", param, want, got)
+		}
+	}
+}
@@ -21,6 +21,7 @@ func tablePrismacloudInventoryAssetView(ctx context.Context) *plugin.Table {
 		Description: "Prisma Cloud inventory asset view.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudInventoryAssetView,
+			Tags:    serviceTags(serviceInventory),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "account_name", Require: plugin.Optional},
 				{Name: "cloud_type_name", Require: plugin.Optional},
@@ -185,6 +186,10 @@ func listPrismacloudInventoryAssetView(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
 	groupBy := "cloud.service"
 	if d.EqualsQualString("group_by") != "" {
 		groupBy = d.EqualsQualString("group_by")
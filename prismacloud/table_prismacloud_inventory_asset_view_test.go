@@ -0,0 +1,39 @@
+package prismacloud
+
+import (
+	"testing"
+)
+
+func TestListInventoryAssetView(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/v3/inventory", "inventory_asset_view.json")
+
+	rows := testQuery{
+		table:   "prismacloud_inventory_asset_view",
+		columns: []string{"service_name", "account_name", "group_by", "total_resources", "failed_resources"},
+		quals:   quals(stringQual("account_name", "=", "aws-prod")),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(rows))
+	}
+	for _, row := range rows {
+		// The API doesn't return the account when grouping by service, so it is set from the qual
+		if row["account_name"].GetStringValue() != "aws-prod" {
+			t.Errorf("expected account_name to be set from the qual, got %v", row)
+		}
+		if row["service_name"].GetStringValue() == "Amazon S3" && (row["total_resources"].GetIntValue() != 40 || row["failed_resources"].GetIntValue() != 10) {
+			t.Errorf("unexpected counts for Amazon S3: %v", row)
+		}
+	}
+
+	requests := m.received("GET", "/v3/inventory")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 inventory request, got %d", len(requests))
+	}
+	if got := requests[0].Query.Get("groupBy"); got != "cloud.service" {
+		t.Errorf("expected the assets to be grouped by cloud.service by default, got %q", got)
+	}
+	if got := requests[0].Query.Get("cloud.account"); got != "aws-prod" {
+		t.Errorf("expected cloud.account=aws-prod, got %q", got)
+	}
+}
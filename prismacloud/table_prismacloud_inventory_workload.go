@@ -15,6 +15,7 @@ func tablePrismacloudInventoryWorkload(ctx context.Context) *plugin.Table {
 		Description: "Prisma Cloud inventory workload summary.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudInventoryWorkloads,
+			Tags:    serviceTags(serviceInventory),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -76,7 +77,17 @@ func listPrismacloudInventoryWorkloads(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
-	resp, err := api.GetInventoryWorkloads(conn.JsonWebToken)
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
+	computeUrl, err := getComputeUrl(ctx, d, conn)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_inventory_workload.listPrismacloudInventoryWorkloads", "connection_error", err)
+		return nil, err
+	}
+
+	resp, err := api.GetInventoryWorkloads(conn, computeUrl)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_inventory_workload.listPrismacloudInventoryWorkloads", "api_error", err)
 		return nil, err
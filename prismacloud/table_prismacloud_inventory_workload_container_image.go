@@ -15,6 +15,7 @@ func tablePrismacloudInventoryWorkloadContainerImage(ctx context.Context) *plugi
 		Description: "Query Prisma Cloud inventory workload container image.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudInventoryWorkloadContainerImages,
+			Tags:    serviceTags(serviceInventory),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -69,7 +70,17 @@ func listPrismacloudInventoryWorkloadContainerImages(ctx context.Context, d *plu
 		return nil, err
 	}
 
-	resp, err := api.GetInventoryWorkloadContainerImages(conn.JsonWebToken, "")
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
+	computeUrl, err := getComputeUrl(ctx, d, conn)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_inventory_workload_container_image.listPrismacloudInventoryWorkloadContainerImages", "connection_error", err)
+		return nil, err
+	}
+
+	resp, err := api.GetInventoryWorkloadContainerImages(conn, computeUrl, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_inventory_workload_container_image.listPrismacloudInventoryWorkloadContainerImages", "api_error", err)
 		return nil, err
@@ -86,7 +97,7 @@ func listPrismacloudInventoryWorkloadContainerImages(ctx context.Context, d *plu
 	}
 
 	for resp.NextPageToken != "" {
-		resp, err = api.GetInventoryWorkloadContainerImages(conn.JsonWebToken, resp.NextPageToken)
+		resp, err = api.GetInventoryWorkloadContainerImages(conn, computeUrl, resp.NextPageToken)
 		if err != nil {
 			plugin.Logger(ctx).Error("prismacloud_inventory_workload_container_image.listPrismacloudInventoryWorkloadContainerImages", "paging_error", err)
 			return nil, err
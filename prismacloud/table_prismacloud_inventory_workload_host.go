@@ -15,6 +15,7 @@ func tablePrismacloudInventoryWorkloadHost(ctx context.Context) *plugin.Table {
 		Description: "Prisma Cloud inventory workload host.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudInventoryWorkloadHosts,
+			Tags:    serviceTags(serviceInventory),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -58,7 +59,17 @@ func listPrismacloudInventoryWorkloadHosts(ctx context.Context, d *plugin.QueryD
 		return nil, err
 	}
 
-	resp, err := api.GetInventoryWorkloadHosts(conn.JsonWebToken, "")
+	if err := waitForRateLimit(ctx, d, serviceInventory); err != nil {
+		return nil, err
+	}
+
+	computeUrl, err := getComputeUrl(ctx, d, conn)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_inventory_workload_host.listPrismacloudInventoryWorkloadHosts", "connection_error", err)
+		return nil, err
+	}
+
+	resp, err := api.GetInventoryWorkloadHosts(conn, computeUrl, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_inventory_workload_host.listPrismacloudInventoryWorkloadHosts", "api_error", err)
 		return nil, err
@@ -75,7 +86,7 @@ func listPrismacloudInventoryWorkloadHosts(ctx context.Context, d *plugin.QueryD
 	}
 
 	for resp.NextPageToken != "" {
-		resp, err = api.GetInventoryWorkloadHosts(conn.JsonWebToken, resp.NextPageToken)
+		resp, err = api.GetInventoryWorkloadHosts(conn, computeUrl, resp.NextPageToken)
 		if err != nil {
 			plugin.Logger(ctx).Error("prismacloud_inventory_workload_host.listPrismacloudInventoryWorkloadHosts", "paging_error", err)
 			return nil, err
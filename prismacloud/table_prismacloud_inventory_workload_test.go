@@ -0,0 +1,44 @@
+package prismacloud
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestInventoryWorkloadUsesComputeUrl(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/compute/api/v1/bff/assets/summary", "inventory_workload.json")
+
+	rows := testQuery{table: "prismacloud_inventory_workload", columns: []string{"container_images_run", "hosts_total"}}.run(t, m)
+	if len(rows) != 1 || rows[0]["container_images_run"].GetIntValue() != 30 || rows[0]["hosts_total"].GetIntValue() != 18 {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if len(m.received("GET", "/meta_info")) != 0 {
+		t.Error("expected compute_url to be used without discovering it from meta_info")
+	}
+}
+
+func TestInventoryWorkloadDiscoversComputeUrl(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/compute/api/v1/bff/assets/summary", "inventory_workload.json")
+	m.handle("GET", "/meta_info", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, map[string]string{"twistlockUrl": m.URL + "/compute/"}
+	})
+	server := newTestPluginWithConfig(t, `
+url      = "`+m.host()+`"
+protocol = "http"
+username = "test-access-key"
+password = "test-secret-key"
+`)
+
+	rows, err := testQuery{table: "prismacloud_inventory_workload", columns: []string{"hosts_total"}}.execute(t, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if len(m.received("GET", "/meta_info")) != 1 {
+		t.Error("expected the compute URL to be discovered from meta_info")
+	}
+}
@@ -0,0 +1,424 @@
+package prismacloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
+)
+
+// Flow logs searched when no time range is given
+const defaultNetworkSearchWindow = 24 * time.Hour
+
+func tablePrismacloudNetworkSearch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_network_search",
+		Description: "Search network reachability and flow logs with an RQL network query.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudNetworkSearch,
+			Tags:    serviceTags(serviceSearch),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "query", Require: plugin.Required},
+				{Name: "start_time", Require: plugin.Optional, Operators: []string{"=", ">=", ">"}},
+				{Name: "end_time", Require: plugin.Optional, Operators: []string{"=", "<=", "<"}},
+				{Name: "time_range", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "query",
+				Description: "The RQL network query, for example network from vpc.flow_record where bytes > 0.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "row_type",
+				Description: "The type of the row, connection for an edge between two nodes or node for a node without connections.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_id",
+				Description: "The ID of the source node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_name",
+				Description: "The name of the source node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_ip",
+				Description: "The IP address of the source node.",
+				Type:        proto.ColumnType_INET,
+				Transform:   fromIpField("SourceIp"),
+			},
+			{
+				Name:        "source_rrn",
+				Description: "The Prisma Cloud resource RRN of the source node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_resource_id",
+				Description: "The cloud resource ID of the source node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_account_id",
+				Description: "The cloud account ID of the source node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_region_id",
+				Description: "The region ID of the source node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_metadata",
+				Description: "The metadata of the source node.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "destination_id",
+				Description: "The ID of the destination node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_name",
+				Description: "The name of the destination node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_ip",
+				Description: "The IP address of the destination node.",
+				Type:        proto.ColumnType_INET,
+				Transform:   fromIpField("DestinationIp"),
+			},
+			{
+				Name:        "destination_rrn",
+				Description: "The Prisma Cloud resource RRN of the destination node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_resource_id",
+				Description: "The cloud resource ID of the destination node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_account_id",
+				Description: "The cloud account ID of the destination node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_region_id",
+				Description: "The region ID of the destination node.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_metadata",
+				Description: "The metadata of the destination node.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "ports",
+				Description: "The destination ports of the connection.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "protocol",
+				Description: "The protocol of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bytes_accepted",
+				Description: "The number of bytes accepted on the connection.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "bytes_rejected",
+				Description: "The number of bytes rejected on the connection.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "bytes_attempted",
+				Description: "The number of bytes attempted on the connection.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "accepted",
+				Description: "Indicates if traffic was accepted on the connection.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "rejected",
+				Description: "Indicates if traffic was rejected on the connection.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "suspicious",
+				Description: "Indicates if the connection or node is flagged as suspicious.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "flow_classes",
+				Description: "The flow classes of the connection.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "search_id",
+				Description: "The unique identifier of the search.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "start_time",
+				Description: "The start of the time range searched, set when the start_time or end_time quals are used.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("StartTime"),
+			},
+			{
+				Name:        "end_time",
+				Description: "The end of the time range searched, set when the start_time or end_time quals are used.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("EndTime"),
+			},
+			{
+				Name:        "time_range",
+				Description: "The relative time range of the search, such as '24 hours', '7 days', 'epoch' or 'login'. Ignored when start_time or end_time is used.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("time_range"),
+			},
+			{
+				Name:        "search_time_range",
+				Description: "The time range of the search, as returned by Prisma Cloud.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("TimeRange"),
+			},
+
+			// Steampipe standard column
+			{
+				Name:        "title",
+				Description: "The title of the row.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Title"),
+			},
+		}),
+	}
+}
+
+// NetworkSearchResult is a connection of the network graph flattened with its source and destination nodes
+type NetworkSearchResult struct {
+	Query     string
+	SearchId  string
+	TimeRange map[string]interface{}
+	StartTime int64
+	EndTime   int64
+	RowType   string
+
+	SourceId         string
+	SourceName       string
+	SourceIp         string
+	SourceRrn        string
+	SourceResourceId string
+	SourceAccountId  string
+	SourceRegionId   string
+	SourceMetadata   map[string]interface{}
+
+	DestinationId         string
+	DestinationName       string
+	DestinationIp         string
+	DestinationRrn        string
+	DestinationResourceId string
+	DestinationAccountId  string
+	DestinationRegionId   string
+	DestinationMetadata   map[string]interface{}
+
+	Ports          []interface{}
+	Protocol       string
+	BytesAccepted  int64
+	BytesRejected  int64
+	BytesAttempted int64
+	Accepted       bool
+	Rejected       bool
+	Suspicious     bool
+	FlowClasses    []string
+	Title          string
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudNetworkSearch(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	query := d.EqualsQualString("query")
+
+	// Empty check
+	if query == "" {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_network_search.listPrismacloudNetworkSearch", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+		return nil, err
+	}
+
+	// Push the start_time and end_time quals or the time_range qual into the search time range
+	var timeRange interface{}
+	start, _ := timeRangeFromQuals(d, "start_time")
+	_, end := timeRangeFromQuals(d, "end_time")
+	if start != 0 || end != 0 {
+		// With only end_time the search starts at the epoch
+		if end == 0 {
+			end = time.Now().UnixMilli()
+		}
+		if start > end {
+			return nil, nil
+		}
+		timeRange = timerange.Absolute{Start: int(start), End: int(end)}
+	} else if d.EqualsQualString("time_range") != "" {
+		timeRange, err = parseRelativeTimeRange(d.EqualsQualString("time_range"))
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_network_search.listPrismacloudNetworkSearch", "time_range_error", err)
+			return nil, err
+		}
+	} else if strings.HasPrefix(strings.ToLower(strings.TrimSpace(query)), "network from") {
+		// Flow logs are searched over the last day, network configuration over its latest state
+		timeRange = timerange.Relative{Amount: int(defaultNetworkSearchWindow.Hours()), Unit: timerange.Hour}
+	} else {
+		timeRange = timerange.Epoch
+	}
+
+	searchTimeRange := timerange.TimeRange{Value: timeRange}
+	if err := searchTimeRange.SetType(); err != nil {
+		return nil, err
+	}
+	req := map[string]interface{}{
+		"query":     query,
+		"timeRange": searchTimeRange,
+	}
+
+	resp, err := api.NetworkSearch(conn, req)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_network_search.listPrismacloudNetworkSearch", "api_error", err)
+		return nil, err
+	}
+
+	for _, row := range flattenNetworkSearch(query, resp) {
+		row.StartTime, row.EndTime = start, end
+
+		d.StreamListItem(ctx, row)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// Flatten the network graph into one row per connection, followed by one row per node without connections
+func flattenNetworkSearch(query string, resp *model.NetworkSearchResponse) []NetworkSearchResult {
+	nodes := map[string]model.NetworkNode{}
+	for _, node := range resp.Data.Nodes {
+		nodes[networkNodeId(node.Id)] = node
+	}
+
+	var rows []NetworkSearchResult
+	connected := map[string]bool{}
+	for _, c := range resp.Data.Connections {
+		from, to := networkNodeId(c.From), networkNodeId(c.To)
+		connected[from], connected[to] = true, true
+
+		row := NetworkSearchResult{
+			Query:          query,
+			SearchId:       resp.Id,
+			TimeRange:      resp.TimeRange,
+			RowType:        "connection",
+			Ports:          c.Metadata.DestPorts,
+			Protocol:       c.Metadata.Protocol,
+			BytesAccepted:  c.Metadata.BytesAccepted,
+			BytesRejected:  c.Metadata.BytesRejected,
+			BytesAttempted: c.Metadata.BytesAttempted,
+			Accepted:       c.Metadata.BytesAccepted > 0,
+			Rejected:       c.Metadata.BytesRejected > 0,
+			Suspicious:     c.Suspicious,
+			FlowClasses:    c.Metadata.FlowClasses,
+		}
+		row.setSource(from, nodes[from])
+		row.setDestination(to, nodes[to])
+		row.Title = fmt.Sprintf("%s -> %s", row.SourceName, row.DestinationName)
+		rows = append(rows, row)
+	}
+
+	for _, node := range resp.Data.Nodes {
+		id := networkNodeId(node.Id)
+		if connected[id] {
+			continue
+		}
+		row := NetworkSearchResult{
+			Query:      query,
+			SearchId:   resp.Id,
+			TimeRange:  resp.TimeRange,
+			RowType:    "node",
+			Suspicious: node.Suspicious,
+			Title:      node.Name,
+		}
+		row.setSource(id, node)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func (r *NetworkSearchResult) setSource(id string, node model.NetworkNode) {
+	r.SourceId = id
+	r.SourceName = node.Name
+	r.SourceIp = node.IpAddr
+	r.SourceRrn = networkNodeMetadataString(node, "rrn")
+	r.SourceResourceId = networkNodeMetadataString(node, "resource_id")
+	r.SourceAccountId = networkNodeMetadataString(node, "account_id")
+	r.SourceRegionId = networkNodeMetadataString(node, "region_id")
+	r.SourceMetadata = node.Metadata
+}
+
+func (r *NetworkSearchResult) setDestination(id string, node model.NetworkNode) {
+	r.DestinationId = id
+	r.DestinationName = node.Name
+	r.DestinationIp = node.IpAddr
+	r.DestinationRrn = networkNodeMetadataString(node, "rrn")
+	r.DestinationResourceId = networkNodeMetadataString(node, "resource_id")
+	r.DestinationAccountId = networkNodeMetadataString(node, "account_id")
+	r.DestinationRegionId = networkNodeMetadataString(node, "region_id")
+	r.DestinationMetadata = node.Metadata
+}
+
+// Node IDs are returned as numbers or strings depending on the query
+func networkNodeId(id interface{}) string {
+	switch v := id.(type) {
+	case nil:
+		return ""
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func networkNodeMetadataString(node model.NetworkNode, key string) string {
+	if v, ok := node.Metadata[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
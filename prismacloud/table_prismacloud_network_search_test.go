@@ -0,0 +1,143 @@
+package prismacloud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListNetworkSearchFlattensGraph(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search", "network_search.json")
+
+	rql := "network from vpc.flow_record where bytes > 0"
+	rows := testQuery{
+		table:   "prismacloud_network_search",
+		columns: []string{"row_type", "source_id", "destination_id", "destination_resource_id", "ports", "protocol", "accepted", "rejected"},
+		quals:   quals(stringQual("query", "=", rql)),
+	}.run(t, m)
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row["row_type"].GetStringValue()]++
+		if row["row_type"].GetStringValue() != "connection" {
+			continue
+		}
+		if row["source_id"].GetStringValue() != "2" || row["destination_resource_id"].GetStringValue() != "i-0abc" {
+			t.Errorf("unexpected connection endpoints: %v", row)
+		}
+		if row["accepted"].GetBoolValue() == row["rejected"].GetBoolValue() {
+			t.Errorf("expected each fixture connection to be either accepted or rejected: %v", row)
+		}
+	}
+	if counts["connection"] != 2 || counts["node"] != 1 {
+		t.Errorf("expected 2 connection rows and 1 unconnected node row, got %v", counts)
+	}
+
+	requests := m.received("POST", "/search")
+	if len(requests) != 1 || requests[0].Body["timeRange"].(map[string]interface{})["type"] != "relative" {
+		t.Errorf("expected a relative time range for a flow log query, got %v", requests)
+	}
+}
+
+func TestListNetworkSearchTimeQuals(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search", "network_search.json")
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	rows := testQuery{
+		table:   "prismacloud_network_search",
+		columns: []string{"row_type", "start_time", "end_time"},
+		quals: quals(
+			stringQual("query", "=", "network from vpc.flow_record where bytes > 0"),
+			timestampQual("start_time", ">=", start),
+			timestampQual("end_time", "<=", end),
+		),
+	}.run(t, m)
+	if len(rows) == 0 || !rows[0]["start_time"].GetTimestampValue().AsTime().Equal(start) || !rows[0]["end_time"].GetTimestampValue().AsTime().Equal(end) {
+		t.Errorf("expected the rows to have the searched time range, got %v", rows)
+	}
+
+	requests := m.received("POST", "/search")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 network search request, got %d", len(requests))
+	}
+	timeRange := requests[0].Body["timeRange"].(map[string]interface{})
+	value, _ := timeRange["value"].(map[string]interface{})
+	if timeRange["type"] != "absolute" || value["startTime"] != float64(start.UnixMilli()) || value["endTime"] != float64(end.UnixMilli()) {
+		t.Errorf("expected an absolute time range from the quals, got %v", timeRange)
+	}
+}
+
+func TestListNetworkSearchEndTimeOnly(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search", "network_search.json")
+
+	end := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	testQuery{
+		table:   "prismacloud_network_search",
+		columns: []string{"row_type", "end_time"},
+		quals: quals(
+			stringQual("query", "=", "network from vpc.flow_record where bytes > 0"),
+			timestampQual("end_time", "<=", end),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/search")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 network search request, got %d", len(requests))
+	}
+	timeRange := requests[0].Body["timeRange"].(map[string]interface{})
+	value, _ := timeRange["value"].(map[string]interface{})
+	if timeRange["type"] != "absolute" || value["startTime"] != float64(0) || value["endTime"] != float64(end.UnixMilli()) {
+		t.Errorf("expected an absolute time range from the epoch to end_time, got %v", timeRange)
+	}
+}
+
+func TestListNetworkSearchRelativeTimeRange(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search", "network_search.json")
+
+	testQuery{
+		table:   "prismacloud_network_search",
+		columns: []string{"row_type", "time_range"},
+		quals: quals(
+			stringQual("query", "=", "network from vpc.flow_record where bytes > 0"),
+			stringQual("time_range", "=", "7 days"),
+		),
+	}.run(t, m)
+
+	requests := m.received("POST", "/search")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 network search request, got %d", len(requests))
+	}
+	timeRange := requests[0].Body["timeRange"].(map[string]interface{})
+	value, _ := timeRange["value"].(map[string]interface{})
+	if timeRange["type"] != "relative" || value["amount"] != float64(7) || value["unit"] != "day" {
+		t.Errorf("expected a relative time range of 7 days, got %v", timeRange)
+	}
+}
+
+func TestListNetworkSearchIpAddresses(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("POST", "/search", "network_search.json")
+
+	rows := testQuery{
+		table:   "prismacloud_network_search",
+		columns: []string{"row_type", "source_id", "source_ip", "destination_ip"},
+		quals:   quals(stringQual("query", "=", "network from vpc.flow_record where bytes > 0")),
+	}.run(t, m)
+
+	for _, row := range rows {
+		if row["row_type"].GetStringValue() != "connection" {
+			continue
+		}
+		// The internet node has no address
+		if row["source_id"].GetStringValue() == "2" && row["source_ip"].GetCidrRangeValue() != "" {
+			t.Errorf("expected a null source IP for the internet node, got %v", row["source_ip"])
+		}
+		if row["destination_ip"].GetCidrRangeValue() != "10.0.1.10" {
+			t.Errorf("unexpected destination IP: %v", row["destination_ip"])
+		}
+	}
+}
@@ -15,10 +15,12 @@ func tablePrismacloudPermissionGroup(ctx context.Context) *plugin.Table {
 		Description: "List of available permission groups.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudPermissionGroup,
+			Tags:       serviceTags(serviceSettings),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudPermissionGroups,
+			Tags:    serviceTags(serviceSettings),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -50,7 +52,7 @@ func tablePrismacloudPermissionGroup(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_ts",
 				Description: "The timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedTs").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedTs"),
 			},
 			{
 				Name:        "accept_account_groups",
@@ -109,6 +111,10 @@ func listPrismacloudPermissionGroups(ctx context.Context, d *plugin.QueryData, _
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	permissions, err := permission_group.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_permission_group.listPrismacloudPermissionGroups", "api_error", err)
@@ -145,6 +151,10 @@ func getPrismacloudPermissionGroup(ctx context.Context, d *plugin.QueryData, h *
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	permission, err := permission_group.Get(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_permission_group.getPrismacloudPermissionGroup", "api_error", err)
@@ -3,12 +3,13 @@ package prismacloud
 import (
 	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/paloaltonetworks/prisma-cloud-go/policy"
-	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
@@ -20,10 +21,12 @@ func tablePrismacloudPolicy(ctx context.Context) *plugin.Table {
 		Description: "List of available policies in Prisma Cloud.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudPolicy,
+			Tags:       serviceTags(serviceSettings),
 			KeyColumns: plugin.SingleColumn("policy_id"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudPolicies,
+			Tags:    serviceTags(serviceSettings),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "cloud_type", Require: plugin.Optional},
 				{Name: "severity", Require: plugin.Optional},
@@ -37,6 +40,9 @@ func tablePrismacloudPolicy(ctx context.Context) *plugin.Table {
 				{Name: "compliance_section_id", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getPrismacloudOpenAlertCountForPolicy, Tags: serviceTags(serviceAlert)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "policy_id",
@@ -110,7 +116,7 @@ func tablePrismacloudPolicy(ctx context.Context) *plugin.Table {
 				Name:        "created_on",
 				Description: "The timestamp when the policy was created.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("CreatedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("CreatedOn"),
 			},
 			{
 				Name:        "created_by",
@@ -121,7 +127,7 @@ func tablePrismacloudPolicy(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_on",
 				Description: "The timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedOn"),
 			},
 			{
 				Name:        "last_modified_by",
@@ -132,7 +138,7 @@ func tablePrismacloudPolicy(ctx context.Context) *plugin.Table {
 				Name:        "rule_last_modified_on",
 				Description: "The timestamp of the last modification to the rule.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("RuleLastModifiedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("RuleLastModifiedOn"),
 			},
 			{
 				Name:        "overridden",
@@ -227,6 +233,10 @@ func listPrismacloudPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	query := buildPrismacloudListPolicyInputQuery(d)
 
 	policies, err := policy.List(conn, query)
@@ -263,6 +273,10 @@ func getPrismacloudPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	policy, err := policy.Get(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_policy.getPrismacloudPolicy", "api_error", err)
@@ -275,60 +289,52 @@ func getPrismacloudPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 func getPrismacloudOpenAlertCountForPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	policy := h.Item.(policy.Policy)
 
-	type filterMap map[string]string
-
-	filters := []filterMap{
-		{"name": "alert.status", "operator": "=", "value": "open"},
-		{"name": "policy.severity", "operator": "=", "value": policy.Severity},
-		{"name": "policy.name", "operator": "=", "value": policy.Name},
-		{"name": "timeRange.type", "operator": "=", "value": "ALERT_UPDATED"},
-	}
-	sortBy := []string{"alertCount:asc", "severity:desc"}
-	timeRange := map[string]string{
-		"type":  "to_now",
-		"value": "epoch",
-	}
-	conn, err := connect(ctx, d)
+	counts, err := getOpenAlertCountByPolicyMemoized(ctx, d, h)
 	if err != nil {
-		plugin.Logger(ctx).Error("prismacloud_policy.getPrismacloudOpenAlertCountForPolicy", "connection_error", err)
 		return nil, err
 	}
 
-	req := map[string]interface{}{
-		"filters":       filters,
-		"sortBy":        sortBy,
-		"timeRange":     timeRange,
-		"nextPageToken": "",
-	}
-	var policies []model.Policy
-	results, err := api.GetAlertCountOfPolicies(conn, req)
+	return counts.(*openAlertCounts).counts[policy.PolicyId], nil
+}
+
+// openAlertCounts are the open alert counts of all the policies, fetched for one query
+type openAlertCounts struct {
+	// Held so the address of the query context isn't reused by another query while the counts are cached
+	queryContext *plugin.QueryContext
+	counts       map[string]int
+}
+
+// The open alert counts of all the policies are fetched once and shared by the rows of a query
+var getOpenAlertCountByPolicyMemoized = plugin.HydrateFunc(getOpenAlertCountByPolicyUncached).Memoize(memoize.WithCacheKeyFunction(getOpenAlertCountByPolicyCacheKey), memoize.WithTtl(5*time.Minute))
+
+// Build a cache key for the call to getOpenAlertCountByPolicy.
+// The key is unique to the connection and the query, so later queries fetch the counts again.
+func getOpenAlertCountByPolicyCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := fmt.Sprintf("getPrismacloudOpenAlertCountByPolicy-%s-%p", d.Connection.Name, d.QueryContext)
+	return key, nil
+}
+
+func getOpenAlertCountByPolicyUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
-			return 0, nil
-		}
-		plugin.Logger(ctx).Error("prismacloud_policy.getPrismacloudOpenAlertCountForPolicy", "api_error", err)
+		plugin.Logger(ctx).Error("prismacloud_policy.getOpenAlertCountByPolicyUncached", "connection_error", err)
 		return nil, err
 	}
 
-	policies = append(policies, results.Policies...)
-
-	for results.NextPageToken != "" {
-		req["nextPageToken"] = results.NextPageToken
-		results, err = api.GetAlertCountOfPolicies(conn, req)
-		if err != nil {
-			plugin.Logger(ctx).Error("prismacloud_policy.getPrismacloudOpenAlertCountForPolicy", "paging_error", err)
-			return nil, err
-		}
-		policies = append(policies, results.Policies...)
+	filters := []map[string]string{
+		{"name": "alert.status", "operator": "=", "value": "open"},
 	}
-
-	for _, p := range policies {
-		if p.PolicyId == policy.PolicyId {
-			return p.AlertCount, nil
-		}
+	counts := map[string]int{}
+	err = listAlertCountOfPolicies(ctx, d, conn, filters, timerange.TimeRange{Type: timerange.TypeToNow, Value: timerange.Epoch}, func(p model.Policy) bool {
+		counts[p.PolicyId] = p.AlertCount
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_policy.getOpenAlertCountByPolicyUncached", "api_error", err)
+		return nil, err
 	}
 
-	return 0, nil
+	return &openAlertCounts{queryContext: d.QueryContext, counts: counts}, nil
 }
 
 //// UTILITY FUNCTION
@@ -0,0 +1,255 @@
+package prismacloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/policy"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudPolicyComplianceMapping(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_policy_compliance_mapping",
+		Description: "List the compliance standards, requirements and sections each Prisma Cloud policy is mapped to.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudPolicyComplianceMappings,
+			Tags:    serviceTags(serviceSettings),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "policy_id", Require: plugin.Optional},
+				{Name: "policy_enabled", Require: plugin.Optional},
+				{Name: "policy_severity", Require: plugin.Optional},
+				{Name: "cloud_type", Require: plugin.Optional},
+				{Name: "standard_id", Require: plugin.Optional},
+				{Name: "standard_name", Require: plugin.Optional},
+				{Name: "requirement_name", Require: plugin.Optional},
+				{Name: "section_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "policy_id",
+				Description: "The unique identifier for the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_name",
+				Description: "The name of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_enabled",
+				Description: "Indicates if the policy is enabled.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "policy_severity",
+				Description: "The severity of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The cloud type of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "standard_id",
+				Description: "The unique identifier for the compliance standard.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("StandardId").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "standard_name",
+				Description: "The name of the compliance standard.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "standard_description",
+				Description: "The description of the compliance standard.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "requirement_id",
+				Description: "The identifier of the requirement within the compliance standard, such as 1.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "requirement_name",
+				Description: "The name of the compliance requirement.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "requirement_description",
+				Description: "The description of the compliance requirement.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "section_id",
+				Description: "The identifier of the section within the requirement, such as 1.1.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "section_uuid",
+				Description: "The unique identifier for the compliance section, matching the id of prismacloud_compliance_section.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ComplianceId"),
+			},
+			{
+				Name:        "section_description",
+				Description: "The description of the compliance section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "section_label",
+				Description: "The label of the compliance section.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "custom_assigned",
+				Description: "Indicates if the policy was assigned to the section by a user.",
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(policyComplianceMappingTitle),
+			},
+		}),
+	}
+}
+
+// PolicyComplianceMapping is a compliance section a policy is mapped to
+type PolicyComplianceMapping struct {
+	PolicyName     string
+	PolicyEnabled  bool
+	PolicySeverity string
+	CloudType      string
+	StandardId     string
+	policy.ComplianceMetadata
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudPolicyComplianceMappings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_policy_compliance_mapping.listPrismacloudPolicyComplianceMappings", "connection_error", err)
+		return nil, err
+	}
+
+	// The compliance metadata of the policies only names the standards, so their IDs are looked up
+	var standardIds map[string]string
+	needStandardIds := d.EqualsQualString("standard_id") != ""
+	for _, column := range d.QueryContext.Columns {
+		if column == "standard_id" {
+			needStandardIds = true
+		}
+	}
+	if needStandardIds {
+		if err := waitForRateLimit(ctx, d, serviceCompliance); err != nil {
+			return nil, err
+		}
+
+		standards, err := api.ListComplianceStandards(conn)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_policy_compliance_mapping.listPrismacloudPolicyComplianceMappings", "api_error", err)
+			return nil, err
+		}
+		standardIds = make(map[string]string, len(standards))
+		for _, s := range standards {
+			standardIds[s.Name] = s.ID
+		}
+	}
+
+	query := map[string]string{}
+	for column, param := range map[string]string{
+		"standard_name":    "policy.complianceStandard",
+		"requirement_name": "policy.complianceRequirement",
+		"section_id":       "policy.complianceSection",
+		"cloud_type":       "cloud.type",
+		"policy_severity":  "policy.severity",
+	} {
+		if d.EqualsQualString(column) != "" {
+			query[param] = d.EqualsQualString(column)
+		}
+	}
+	if d.EqualsQuals["policy_enabled"] != nil {
+		query["policy.enabled"] = fmt.Sprint(d.EqualsQuals["policy_enabled"].GetBoolValue())
+	}
+	if id := d.EqualsQualString("standard_id"); id != "" {
+		name := ""
+		for n, standardId := range standardIds {
+			if standardId == id {
+				name = n
+			}
+		}
+		// No standard has the given ID
+		if name == "" || (query["policy.complianceStandard"] != "" && query["policy.complianceStandard"] != name) {
+			return nil, nil
+		}
+		query["policy.complianceStandard"] = name
+	}
+
+	var policies []policy.Policy
+	if id := d.EqualsQualString("policy_id"); id != "" {
+		if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+			return nil, err
+		}
+
+		p, err := policy.Get(conn, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "object not found") {
+				return nil, nil
+			}
+			plugin.Logger(ctx).Error("prismacloud_policy_compliance_mapping.listPrismacloudPolicyComplianceMappings", "api_error", err)
+			return nil, err
+		}
+		policies = append(policies, p)
+	} else {
+		if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+			return nil, err
+		}
+
+		policies, err = policy.List(conn, query)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_policy_compliance_mapping.listPrismacloudPolicyComplianceMappings", "api_error", err)
+			return nil, err
+		}
+	}
+
+	for _, p := range policies {
+		for _, metadata := range p.ComplianceMetadata {
+			// The policy ID isn't always set in the metadata of a policy
+			metadata.PolicyId = p.PolicyId
+			d.StreamListItem(ctx, PolicyComplianceMapping{
+				PolicyName:         p.Name,
+				PolicyEnabled:      p.Enabled,
+				PolicySeverity:     p.Severity,
+				CloudType:          p.CloudType,
+				StandardId:         standardIds[metadata.StandardName],
+				ComplianceMetadata: metadata,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func policyComplianceMappingTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	m := d.HydrateItem.(PolicyComplianceMapping)
+	return fmt.Sprintf("%s %s", m.StandardName, m.SectionId), nil
+}
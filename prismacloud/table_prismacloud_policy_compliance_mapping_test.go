@@ -0,0 +1,76 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testMappedPolicies = `[
+  {"policyId": "policy-1", "name": "S3 bucket is public", "severity": "high", "cloudType": "aws", "enabled": true, "complianceMetadata": [
+    {"standardName": "CIS AWS", "requirementId": "2", "requirementName": "Storage", "sectionId": "2.1", "complianceId": "sec-1"},
+    {"standardName": "PCI DSS", "requirementId": "1", "requirementName": "Network", "sectionId": "1.3", "complianceId": "sec-4"}
+  ]},
+  {"policyId": "policy-2", "name": "MFA is disabled", "severity": "medium", "cloudType": "aws", "enabled": false, "complianceMetadata": [
+    {"standardName": "CIS AWS", "requirementId": "1", "requirementName": "Identity", "sectionId": "1.1", "complianceId": "sec-2"}
+  ]},
+  {"policyId": "policy-3", "name": "Unmapped", "severity": "low", "cloudType": "aws", "enabled": true}
+]`
+
+func TestListPolicyComplianceMappings(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/v2/policy", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testMappedPolicies)
+	})
+	m.handle("GET", "/compliance", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "std-1", "name": "CIS AWS"}, {"id": "std-2", "name": "PCI DSS"}]`)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_policy_compliance_mapping",
+		columns: []string{"policy_id", "policy_enabled", "standard_id", "standard_name", "requirement_id", "section_id", "section_uuid"},
+	}.run(t, m)
+
+	var mappings []string
+	for _, row := range rows {
+		mappings = append(mappings, strings.Join([]string{
+			row["policy_id"].GetStringValue(),
+			row["standard_id"].GetStringValue(),
+			row["requirement_id"].GetStringValue(),
+			row["section_id"].GetStringValue(),
+			row["section_uuid"].GetStringValue(),
+		}, " "))
+	}
+	sort.Strings(mappings)
+	expected := []string{
+		"policy-1 std-1 2 2.1 sec-1",
+		"policy-1 std-2 1 1.3 sec-4",
+		"policy-2 std-1 1 1.1 sec-2",
+	}
+	if strings.Join(mappings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected mappings:\n%s", strings.Join(mappings, "\n"))
+	}
+}
+
+func TestListPolicyComplianceMappingsByStandardId(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/v2/policy", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testMappedPolicies)
+	})
+	m.handle("GET", "/compliance", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(`[{"id": "std-1", "name": "CIS AWS"}, {"id": "std-2", "name": "PCI DSS"}]`)
+	})
+
+	testQuery{
+		table:   "prismacloud_policy_compliance_mapping",
+		columns: []string{"policy_id", "section_id"},
+		quals:   quals(stringQual("standard_id", "=", "std-2"), boolQual("policy_enabled", "=", true)),
+	}.run(t, m)
+
+	// The standard ID is passed to the API as the name of the standard
+	requests := m.received("GET", "/v2/policy")
+	if len(requests) != 1 || requests[0].Query.Get("policy.complianceStandard") != "PCI DSS" || requests[0].Query.Get("policy.enabled") != "true" {
+		t.Errorf("unexpected policy requests: %v", requests)
+	}
+}
@@ -0,0 +1,83 @@
+package prismacloud
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListPoliciesQualPushdown(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/v2/policy", "policies.json")
+
+	rows := testQuery{
+		table:   "prismacloud_policy",
+		columns: []string{"policy_id", "name", "severity"},
+		quals: quals(
+			stringQual("severity", "=", "high"),
+			stringQual("cloud_type", "=", "aws"),
+			boolQual("enabled", "=", true),
+		),
+	}.run(t, m)
+	if len(rows) != 1 || rows[0]["policy_id"].GetStringValue() != "policy-1" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	requests := m.received("GET", "/v2/policy")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 policy list request, got %d", len(requests))
+	}
+	query := requests[0].Query
+	for param, want := range map[string]string{"policy.severity": "high", "cloud.type": "aws", "policy.enabled": "true"} {
+		if got := query.Get(param); got != want {
+			t.Errorf("expected %s=%s, got %q", param, want, got)
+		}
+	}
+}
+
+func TestPolicyOpenAlertsCountIsFetchedOncePerQuery(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/v2/policy", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []map[string]interface{}{
+			{"policyId": "policy-1", "name": "AWS S3 bucket publicly readable", "severity": "high"},
+			{"policyId": "policy-2", "name": "AWS security group allows all traffic", "severity": "medium"},
+			{"policyId": "policy-3", "name": "AWS IAM password policy", "severity": "low"},
+		}
+	})
+	m.handleFixture("POST", "/alert/v1/policy", "alert_policy_counts.json")
+	server := newTestPlugin(t, m, "")
+
+	query := testQuery{
+		table:   "prismacloud_policy",
+		columns: []string{"policy_id", "open_alerts_count"},
+	}
+	rows, err := query.execute(t, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[string]int64{}
+	for _, row := range rows {
+		counts[row["policy_id"].GetStringValue()] = row["open_alerts_count"].GetIntValue()
+	}
+	if want := map[string]int64{"policy-1": 7, "policy-2": 2, "policy-3": 0}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("expected open alert counts %v, got %v", want, counts)
+	}
+
+	requests := m.received("POST", "/alert/v1/policy")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 alert count request for all the policies, got %d", len(requests))
+	}
+	filters := requests[0].Body["filters"].([]interface{})
+	if f := filters[0].(map[string]interface{}); f["name"] != "alert.status" || f["value"] != "open" {
+		t.Errorf("expected an open alert status filter, got %v", filters)
+	}
+
+	// A later query fetches the counts again rather than reusing those of the first query
+	if _, err := query.execute(t, server); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(m.received("POST", "/alert/v1/policy")); n != 2 {
+		t.Errorf("expected a second alert count request for the second query, got %d", n)
+	}
+}
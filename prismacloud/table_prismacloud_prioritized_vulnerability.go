@@ -18,6 +18,7 @@ func tablePrismacloudPrioritizedVulnerability(ctx context.Context) *plugin.Table
 		Description: "Returns the top-priority vulnerabilities which are aggregated based on the most urgent, exploitable, patchable, and vulnerable packages in use along with the number of assets they occur in.",
 		List: &plugin.ListConfig{
 			Hydrate: getPrismacloudPrioritizedVulnerabilities,
+			Tags:    serviceTags(serviceVulnerability),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "asset_type", Require: plugin.Required, CacheMatch: query_cache.CacheMatchExact},
 				{Name: "life_cycle", Require: plugin.Required, CacheMatch: query_cache.CacheMatchExact},
@@ -40,7 +41,7 @@ func tablePrismacloudPrioritizedVulnerability(ctx context.Context) *plugin.Table
 				Name:        "last_updated_date_time",
 				Description: "The timestamp when the data was last updated.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastUpdatedDateTime").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastUpdatedDateTime"),
 			},
 			{
 				Name:        "total_vulnerabilities",
@@ -120,6 +121,10 @@ func getPrismacloudPrioritizedVulnerabilities(ctx context.Context, d *plugin.Que
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceVulnerability); err != nil {
+		return nil, err
+	}
+
 	query := buildPrioritizedVulnerabilitiesQueryParameter(ctx, d)
 
 	vulnerability, err := api.GetPrioritizedVulnerability(conn, query)
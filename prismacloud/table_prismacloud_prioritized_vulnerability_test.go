@@ -0,0 +1,37 @@
+package prismacloud
+
+import (
+	"testing"
+)
+
+func TestListPrioritizedVulnerabilities(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/uve/api/v4/dashboard/vulnerabilities/prioritised", "prioritized_vulnerability.json")
+
+	rows := testQuery{
+		table:   "prismacloud_prioritized_vulnerability",
+		columns: []string{"asset_type", "life_cycle", "total_vulnerabilities", "urgent_vulnerability_count", "internet_exposed_asset_count", "last_updated_date_time"},
+		quals: quals(
+			stringQual("asset_type", "=", "host"),
+			stringQual("life_cycle", "=", "run"),
+		),
+	}.run(t, m)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row["asset_type"].GetStringValue() != "host" || row["life_cycle"].GetStringValue() != "run" {
+		t.Errorf("expected asset_type and life_cycle to be set from the quals, got %v", row)
+	}
+	if row["total_vulnerabilities"].GetIntValue() != 950 || row["urgent_vulnerability_count"].GetIntValue() != 12 || row["internet_exposed_asset_count"].GetIntValue() != 6 {
+		t.Errorf("unexpected counts: %v", row)
+	}
+	if row["last_updated_date_time"].GetTimestampValue().AsTime().UnixMilli() != 1714557600000 {
+		t.Errorf("unexpected last_updated_date_time: %v", row["last_updated_date_time"])
+	}
+
+	requests := m.received("GET", "/uve/api/v4/dashboard/vulnerabilities/prioritised")
+	if len(requests) != 1 || requests[0].Query.Get("asset_type") != "host" || requests[0].Query.Get("life_cycle") != "run" {
+		t.Errorf("expected the quals to be passed as query parameters, got %v", requests)
+	}
+}
@@ -15,10 +15,12 @@ func tablePrismacloudReport(ctx context.Context) *plugin.Table {
 		Description: "List of available alert and compliance reports.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudReport,
+			Tags:       serviceTags(serviceSettings),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudReports,
+			Tags:    serviceTags(serviceSettings),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -60,7 +62,7 @@ func tablePrismacloudReport(ctx context.Context) *plugin.Table {
 				Name:        "created_on",
 				Description: "The timestamp when the report was created.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("CreatedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("CreatedOn"),
 			},
 			{
 				Name:        "created_by",
@@ -71,7 +73,7 @@ func tablePrismacloudReport(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_on",
 				Description: "The timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedOn").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedOn"),
 			},
 			{
 				Name:        "last_modified_by",
@@ -82,13 +84,13 @@ func tablePrismacloudReport(ctx context.Context) *plugin.Table {
 				Name:        "next_schedule",
 				Description: "The timestamp of the next scheduled run.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("NextSchedule").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("NextSchedule"),
 			},
 			{
 				Name:        "last_scheduled",
 				Description: "The timestamp of the last scheduled run.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastSchedule").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastSchedule"),
 			},
 			{
 				Name:        "total_instance_count",
@@ -121,6 +123,10 @@ func listPrismacloudReports(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	reports, err := report.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_report.listPrismacloudReports", "api_error", err)
@@ -157,6 +163,10 @@ func getPrismacloudReport(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	report, err := report.Get(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_report.getPrismacloudReport", "api_error", err)
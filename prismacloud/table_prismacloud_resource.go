@@ -15,10 +15,12 @@ func tablePrismacloudResource(ctx context.Context) *plugin.Table {
 		Description: "List of available resources in Prisma Cloud.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudResource,
+			Tags:       serviceTags(serviceSettings),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudResources,
+			Tags:    serviceTags(serviceSettings),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -50,7 +52,7 @@ func tablePrismacloudResource(ctx context.Context) *plugin.Table {
 				Name:        "last_modified_ts",
 				Description: "The timestamp of the last modification.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("LastModifiedTs").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("LastModifiedTs"),
 			},
 			{
 				Name:        "members",
@@ -78,6 +80,10 @@ func listPrismacloudResources(ctx context.Context, d *plugin.QueryData, _ *plugi
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	resources, err := resource.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_resource.listPrismacloudResources", "api_error", err)
@@ -114,6 +120,10 @@ func getPrismacloudResource(ctx context.Context, d *plugin.QueryData, h *plugin.
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	resource, err := resource.Get(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_resource.getPrismacloudResource", "api_error", err)
@@ -0,0 +1,301 @@
+package prismacloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/paloaltonetworks/prisma-cloud-go/rql/history"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
+	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Number of results fetched per page when executing a saved search
+const savedSearchPageSize = 1000
+
+func tablePrismacloudSavedSearch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_saved_search",
+		Description: "List the saved RQL searches and run them on demand.",
+		Get: &plugin.GetConfig{
+			Hydrate:    getPrismacloudSavedSearch,
+			Tags:       serviceTags(serviceSearch),
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudSavedSearches,
+			Tags:    serviceTags(serviceSearch),
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: executePrismacloudSavedSearch, Tags: serviceTags(serviceSearch)},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The unique identifier of the saved search.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.Id"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the saved search.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.Name"),
+			},
+			{
+				Name:        "query",
+				Description: "The RQL query of the saved search.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.Query"),
+			},
+			{
+				Name:        "search_type",
+				Description: "The type of the search, one of config, audit_event, network or iam.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.SearchType"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the saved search.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.Description"),
+			},
+			{
+				Name:        "cloud_type",
+				Description: "The cloud type of the saved search.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.CloudType"),
+			},
+			{
+				Name:        "saved",
+				Description: "Indicates if the search is saved.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Model.Saved"),
+			},
+			{
+				Name:        "time_range",
+				Description: "The time range of the saved search.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Model.TimeRange"),
+			},
+			{
+				Name:        "created_by",
+				Description: "The user who created the saved search.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_modified_by",
+				Description: "The user who last modified the saved search.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "results",
+				Description: "The results of running the saved search.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     executePrismacloudSavedSearch,
+				Transform:   transform.FromValue(),
+			},
+
+			// Steampipe standard column
+			{
+				Name:        "title",
+				Description: "The title of the saved search.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Model.Name"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudSavedSearches(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_saved_search.listPrismacloudSavedSearches", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+		return nil, err
+	}
+
+	searches, err := history.List(conn, history.Saved, 0)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_saved_search.listPrismacloudSavedSearches", "api_error", err)
+		return nil, err
+	}
+
+	for _, search := range searches {
+
+		d.StreamListItem(ctx, search)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPrismacloudSavedSearch(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+
+	// Empty check
+	if id == "" {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_saved_search.getPrismacloudSavedSearch", "connection_error", err)
+		return nil, err
+	}
+
+	if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+		return nil, err
+	}
+
+	// The saved search list is the only source of the creator and last modifier of a search
+	searches, err := history.List(conn, history.Saved, 0)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_saved_search.getPrismacloudSavedSearch", "api_error", err)
+		return nil, err
+	}
+
+	for _, search := range searches {
+		if search.Model.Id == id {
+			return search, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Run the saved query with the search API matching its search type
+func executePrismacloudSavedSearch(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	search := h.Item.(history.NameId).Model
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_saved_search.executePrismacloudSavedSearch", "connection_error", err)
+		return nil, err
+	}
+
+	// Searches without a time range run against the latest data
+	var timeRange interface{} = search.TimeRange
+	if search.TimeRange.Type == "" || search.TimeRange.Value == nil {
+		timeRange = map[string]interface{}{
+			"type":  "to_now",
+			"value": "epoch",
+		}
+	}
+	req := map[string]interface{}{
+		"query":     search.Query,
+		"timeRange": timeRange,
+		"limit":     savedSearchPageSize,
+	}
+
+	switch search.SearchType {
+	case "config":
+		if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+			return nil, err
+		}
+		req["withResourceJson"] = true
+		resp, err := api.ConfigSearch(conn, req)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_saved_search.executePrismacloudSavedSearch", "api_error", err)
+			return nil, err
+		}
+		items := resp.Data.Items
+
+		pageToken := resp.Data.NextPageToken
+		for pageToken != "" {
+			if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+				return nil, err
+			}
+
+			page, err := api.ConfigSearchPage(conn, map[string]interface{}{
+				"limit":            savedSearchPageSize,
+				"pageToken":        pageToken,
+				"withResourceJson": true,
+			})
+			if err != nil {
+				plugin.Logger(ctx).Error("prismacloud_saved_search.executePrismacloudSavedSearch", "api_paging_error", err)
+				return nil, err
+			}
+			items = append(items, page.Items...)
+			pageToken = page.NextPageToken
+		}
+		return items, nil
+	case "audit_event", "event":
+		req["withResourceJson"] = true
+		events := []map[string]interface{}{}
+		for {
+			if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+				return nil, err
+			}
+
+			resp, err := api.EventSearch(conn, req)
+			if err != nil {
+				plugin.Logger(ctx).Error("prismacloud_saved_search.executePrismacloudSavedSearch", "api_error", err)
+				return nil, err
+			}
+			for _, item := range resp.Data.Items {
+				events = append(events, item.Raw)
+			}
+
+			if resp.Data.NextPageToken == "" {
+				return events, nil
+			}
+			req["nextPageToken"] = resp.Data.NextPageToken
+		}
+	case "network":
+		if err := waitForRateLimit(ctx, d, serviceSearch); err != nil {
+			return nil, err
+		}
+		delete(req, "limit")
+		resp, err := api.NetworkSearch(conn, req)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_saved_search.executePrismacloudSavedSearch", "api_error", err)
+			return nil, err
+		}
+		return resp.Data, nil
+	case "iam":
+		query := url.Values{
+			"limit": []string{fmt.Sprint(savedSearchPageSize)},
+		}
+		iamReq := map[string]interface{}{
+			"query":         search.Query,
+			"groupByFields": iamPermissionGroupByFields,
+		}
+		var items []model.Item
+		for {
+			if err := waitForRateLimit(ctx, d, serviceIAMSearch); err != nil {
+				return nil, err
+			}
+
+			resp, err := api.ListIAMPermissions(conn, query, iamReq)
+			if err != nil {
+				plugin.Logger(ctx).Error("prismacloud_saved_search.executePrismacloudSavedSearch", "api_error", err)
+				return nil, err
+			}
+			items = append(items, resp.Data.Items...)
+
+			if resp.Data.NextPageToken == "" {
+				return items, nil
+			}
+			iamReq["nextPageToken"] = resp.Data.NextPageToken
+		}
+	}
+
+	plugin.Logger(ctx).Warn("prismacloud_saved_search.executePrismacloudSavedSearch", "unsupported_search_type", search.SearchType)
+	return nil, nil
+}
@@ -0,0 +1,74 @@
+package prismacloud
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSavedSearchResultsBySearchType(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/search/history", "saved_searches.json")
+	m.handleFixture("POST", "/search/config", "config_search.json")
+	m.handleFixture("POST", "/search/config/page", "config_search_page2.json")
+	m.handleFixture("POST", "/iam/api/v4/search/permission", "iam_permissions_page2.json")
+
+	rows := testQuery{table: "prismacloud_saved_search", columns: []string{"id", "search_type", "created_by", "results"}}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	// The config search has a second page of results
+	expected := map[string]int{"saved-config-1": 2, "saved-iam-1": 1}
+	for _, row := range rows {
+		var results []map[string]interface{}
+		if err := json.Unmarshal(row["results"].GetJsonValue(), &results); err != nil {
+			t.Fatalf("invalid results for %s: %v", row["id"].GetStringValue(), err)
+		}
+		if len(results) != expected[row["id"].GetStringValue()] {
+			t.Errorf("expected %d results for %s, got %d", expected[row["id"].GetStringValue()], row["id"].GetStringValue(), len(results))
+		}
+	}
+
+	if got := m.received("GET", "/search/history"); len(got) != 1 || got[0].Query.Get("filter") != "saved" {
+		t.Errorf("expected saved searches to be listed, got %v", got)
+	}
+	if got := m.received("POST", "/search/config"); len(got) != 1 || got[0].Body["query"] != "config from cloud.resource where api.name = 'aws-s3api-get-bucket-acl'" {
+		t.Errorf("expected the config search to be run, got %v", got)
+	}
+	if got := m.received("POST", "/iam/api/v4/search/permission"); len(got) != 1 || got[0].Body["query"] != "config from iam where source.cloud.service.name = 'ec2'" {
+		t.Errorf("expected the IAM search to be run, got %v", got)
+	}
+}
+
+func TestSavedSearchListWithoutResults(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/search/history", "saved_searches.json")
+
+	rows := testQuery{table: "prismacloud_saved_search", columns: []string{"id", "name", "query"}}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if len(m.received("POST", "/search/config")) != 0 {
+		t.Error("expected the saved searches not to run unless the results column is selected")
+	}
+}
+
+func TestGetSavedSearch(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/search/history", "saved_searches.json")
+
+	rows := testQuery{
+		table:   "prismacloud_saved_search",
+		columns: []string{"id", "name", "created_by", "last_modified_by"},
+		quals:   quals(stringQual("id", "=", "saved-iam-1")),
+	}.run(t, m)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if got := rows[0]["created_by"].GetStringValue(); got != "john.smith@example.com" {
+		t.Errorf("expected created_by to be filled in, got %q", got)
+	}
+	if got := rows[0]["last_modified_by"].GetStringValue(); got != "jane.doe@example.com" {
+		t.Errorf("expected last_modified_by to be filled in, got %q", got)
+	}
+}
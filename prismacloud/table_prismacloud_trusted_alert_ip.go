@@ -15,10 +15,12 @@ func tablePrismacloudTrustedAlertIp(ctx context.Context) *plugin.Table {
 		Description: "List of trusted alert IPs in Prisma Cloud.",
 		Get: &plugin.GetConfig{
 			Hydrate:    getPrismacloudTrustedAlertIp,
+			Tags:       serviceTags(serviceSettings),
 			KeyColumns: plugin.SingleColumn("uuid"),
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudTrustedAlertIps,
+			Tags:    serviceTags(serviceSettings),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -64,6 +66,10 @@ func listPrismacloudTrustedAlertIps(ctx context.Context, d *plugin.QueryData, _
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	alertIps, err := alertIp.List(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_trusted_alert_ip.listPrismacloudTrustedAlertIps", "api_error", err)
@@ -100,6 +106,10 @@ func getPrismacloudTrustedAlertIp(ctx context.Context, d *plugin.QueryData, h *p
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+		return nil, err
+	}
+
 	alertIp, err := alertIp.Get(conn, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_trusted_alert_ip.getPrismacloudTrustedAlertIp", "api_error", err)
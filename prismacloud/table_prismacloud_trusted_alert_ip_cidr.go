@@ -0,0 +1,130 @@
+package prismacloud
+
+import (
+	"context"
+	"strings"
+
+	alertIp "github.com/paloaltonetworks/prisma-cloud-go/trusted-alert-ip"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tablePrismacloudTrustedAlertIpCidr(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "prismacloud_trusted_alert_ip_cidr",
+		Description: "List the CIDR blocks of the trusted alert IPs in Prisma Cloud.",
+		List: &plugin.ListConfig{
+			Hydrate: listPrismacloudTrustedAlertIpCidrs,
+			Tags:    serviceTags(serviceSettings),
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "trusted_alert_ip_uuid", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "cidr",
+				Description: "The CIDR block.",
+				Type:        proto.ColumnType_CIDR,
+				Transform:   fromCidrField("CIDR"),
+			},
+			{
+				Name:        "uuid",
+				Description: "The unique identifier of the CIDR block.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("UUID"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the CIDR block.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "created_on",
+				Description: "The timestamp when the CIDR block was added.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   fromEpochMsField("CreatedOn"),
+			},
+			{
+				Name:        "trusted_alert_ip_uuid",
+				Description: "The unique identifier of the trusted alert IP.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TrustedAlertIpUUID"),
+			},
+			{
+				Name:        "trusted_alert_ip_name",
+				Description: "The name of the trusted alert IP.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard column
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CIDR"),
+			},
+		}),
+	}
+}
+
+// TrustedAlertIpCidr is a CIDR block of a trusted alert IP
+type TrustedAlertIpCidr struct {
+	TrustedAlertIpUUID string
+	TrustedAlertIpName string
+	alertIp.CIDRS
+}
+
+//// LIST FUNCTION
+
+func listPrismacloudTrustedAlertIpCidrs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("prismacloud_trusted_alert_ip_cidr.listPrismacloudTrustedAlertIpCidrs", "connection_error", err)
+		return nil, err
+	}
+
+	var alertIps []alertIp.TrustedAlertIP
+	if id := d.EqualsQualString("trusted_alert_ip_uuid"); id != "" {
+		if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+			return nil, err
+		}
+
+		item, err := alertIp.Get(conn, id)
+		if err != nil {
+			if strings.Contains(err.Error(), "object not found") {
+				return nil, nil
+			}
+			plugin.Logger(ctx).Error("prismacloud_trusted_alert_ip_cidr.listPrismacloudTrustedAlertIpCidrs", "api_error", err)
+			return nil, err
+		}
+		alertIps = append(alertIps, item)
+	} else {
+		if err := waitForRateLimit(ctx, d, serviceSettings); err != nil {
+			return nil, err
+		}
+
+		alertIps, err = alertIp.List(conn)
+		if err != nil {
+			plugin.Logger(ctx).Error("prismacloud_trusted_alert_ip_cidr.listPrismacloudTrustedAlertIpCidrs", "api_error", err)
+			return nil, err
+		}
+	}
+
+	for _, item := range alertIps {
+		for _, cidr := range item.CIDRS {
+			d.StreamListItem(ctx, TrustedAlertIpCidr{
+				TrustedAlertIpUUID: item.UUID,
+				TrustedAlertIpName: item.Name,
+				CIDRS:              cidr,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,46 @@
+package prismacloud
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+)
+
+const testTrustedAlertIps = `[
+  {"uuid": "ip-1", "name": "Office", "cidrCount": 2, "cidrs": [
+    {"cidr": "203.0.113.0/24", "uuid": "cidr-1", "createdOn": 1714521600000, "description": "HQ"},
+    {"cidr": "198.51.100.7/24", "uuid": "cidr-2", "createdOn": 1714521600000}
+  ]},
+  {"uuid": "ip-2", "name": "VPN", "cidrCount": 1, "cidrs": [
+    {"cidr": "192.0.2.10", "uuid": "cidr-3"}
+  ]}
+]`
+
+func TestListTrustedAlertIpCidrs(t *testing.T) {
+	m := newMockServer(t)
+	m.handle("GET", "/allow_list/network", func(r mockRequest) (int, interface{}) {
+		return http.StatusOK, []byte(testTrustedAlertIps)
+	})
+
+	rows := testQuery{
+		table:   "prismacloud_trusted_alert_ip_cidr",
+		columns: []string{"trusted_alert_ip_name", "cidr", "created_on"},
+	}.run(t, m)
+
+	var cidrs []string
+	for _, row := range rows {
+		cidrs = append(cidrs, row["trusted_alert_ip_name"].GetStringValue()+" "+row["cidr"].GetCidrRangeValue())
+	}
+	sort.Strings(cidrs)
+	// Host bits are cleared so the values are valid CIDR blocks
+	expected := []string{"Office 198.51.100.0/24", "Office 203.0.113.0/24", "VPN 192.0.2.10/32"}
+	if len(cidrs) != len(expected) {
+		t.Fatalf("unexpected CIDRs: %v", cidrs)
+	}
+	for i := range expected {
+		if cidrs[i] != expected[i] {
+			t.Errorf("unexpected CIDRs: %v", cidrs)
+			break
+		}
+	}
+}
@@ -16,6 +16,7 @@ func tablePrismacloudVulnerabilityAsset(ctx context.Context) *plugin.Table {
 		Description: "The asset summary of vulnerability.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudVulnerabilityAsset,
+			Tags:    serviceTags(serviceVulnerability),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "asset_type", Require: plugin.Optional},
 				{Name: "life_cycle", Require: plugin.Optional},
@@ -68,6 +69,10 @@ func listPrismacloudVulnerabilityAsset(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceVulnerability); err != nil {
+		return nil, err
+	}
+
 	query := buildVulnerabilityAssetsQueryParameter(ctx, d)
 
 	assets, err := api.ListVulnerabilityAssets(conn, query)
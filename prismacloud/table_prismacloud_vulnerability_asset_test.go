@@ -0,0 +1,43 @@
+package prismacloud
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestListVulnerabilityAssets(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/uve/api/v1/dashboard/vulnerabilities/vulnerableAsset", "vulnerability_assets.json")
+
+	rows := testQuery{
+		table:   "prismacloud_vulnerability_asset",
+		columns: []string{"asset_type", "life_cycle", "total_vulnerabilities", "total_assets", "stats"},
+		quals:   quals(stringQual("life_cycle", "=", "run")),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 asset types, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["life_cycle"].GetStringValue() != "run" {
+			t.Errorf("unexpected life_cycle: %v", row)
+		}
+		if row["asset_type"].GetStringValue() != "host" {
+			continue
+		}
+		if row["total_vulnerabilities"].GetIntValue() != 420 || row["total_assets"].GetIntValue() != 30 {
+			t.Errorf("unexpected host totals: %v", row)
+		}
+		var stats []map[string]interface{}
+		if err := json.Unmarshal(row["stats"].GetJsonValue(), &stats); err != nil {
+			t.Fatal(err)
+		}
+		if len(stats) != 2 || stats[0]["provider"] != "aws" {
+			t.Errorf("unexpected host stats: %v", stats)
+		}
+	}
+
+	requests := m.received("GET", "/uve/api/v1/dashboard/vulnerabilities/vulnerableAsset")
+	if len(requests) != 1 || requests[0].Query.Get("life_cycle") != "run" {
+		t.Errorf("expected life_cycle to be passed as a query parameter, got %v", requests)
+	}
+}
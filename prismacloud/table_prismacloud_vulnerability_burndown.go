@@ -16,6 +16,7 @@ func tablePrismacloudVulnerabilityBurndown(ctx context.Context) *plugin.Table {
 		Description: "The burndown summary of vulnerability.",
 		List: &plugin.ListConfig{
 			Hydrate: listPrismacloudVulnerabilityBurndown,
+			Tags:    serviceTags(serviceVulnerability),
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "asset_type", Require: plugin.Required, CacheMatch: query_cache.CacheMatchExact},
 				{Name: "life_cycle", Require: plugin.Required, CacheMatch: query_cache.CacheMatchExact},
@@ -60,7 +61,7 @@ func tablePrismacloudVulnerabilityBurndown(ctx context.Context) *plugin.Table {
 				Name:        "epoch_timestamp",
 				Description: "Time up to which the entry was recorded.",
 				Type:        proto.ColumnType_TIMESTAMP,
-				Transform:   transform.FromField("EpochTimestamp").Transform(transform.NullIfZeroValue).Transform(transform.UnixMsToTimestamp),
+				Transform:   fromEpochMsField("EpochTimestamp"),
 			},
 		}),
 	}
@@ -75,6 +76,10 @@ func listPrismacloudVulnerabilityBurndown(ctx context.Context, d *plugin.QueryDa
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceVulnerability); err != nil {
+		return nil, err
+	}
+
 	query := buildBurndownVulnerabilitiesQueryParameter(ctx, d)
 
 	vulnerability, err := api.ListVulnerabilityBurndown(conn, query)
@@ -0,0 +1,41 @@
+package prismacloud
+
+import (
+	"testing"
+)
+
+func TestListVulnerabilityBurndown(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/uve/api/v2/dashboard/vulnerabilities/burndown", "vulnerability_burndown.json")
+
+	rows := testQuery{
+		table:   "prismacloud_vulnerability_burndown",
+		columns: []string{"asset_type", "life_cycle", "severities", "day_num", "total_count", "remediated_count", "epoch_timestamp"},
+		quals: quals(
+			stringQual("asset_type", "=", "host"),
+			stringQual("life_cycle", "=", "run"),
+			stringQual("severities", "=", "critical,high"),
+		),
+	}.run(t, m)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row["severities"].GetStringValue() != "critical,high" {
+			t.Errorf("expected severities to be set from the qual, got %v", row)
+		}
+		if row["day_num"].GetIntValue() == 2 && (row["total_count"].GetIntValue() != 950 || row["remediated_count"].GetIntValue() != 310 || row["epoch_timestamp"].GetTimestampValue().AsTime().UnixMilli() != 1714557600000) {
+			t.Errorf("unexpected burndown of day 2: %v", row)
+		}
+	}
+
+	requests := m.received("GET", "/uve/api/v2/dashboard/vulnerabilities/burndown")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 burndown request, got %d", len(requests))
+	}
+	for param, want := range map[string]string{"asset_type": "host", "life_cycle": "run", "severities": "critical,high"} {
+		if got := requests[0].Query.Get(param); got != want {
+			t.Errorf("expected %s=%s, got %q", param, want, got)
+		}
+	}
+}
@@ -17,6 +17,7 @@ func tablePrismacloudVulnerabilityOverview(ctx context.Context) *plugin.Table {
 		Description: "Provides an overview summary of vulnerabilities in the environment.",
 		List: &plugin.ListConfig{
 			Hydrate: getPrismacloudVulnerabilityOverview,
+			Tags:    serviceTags(serviceVulnerability),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -55,6 +56,10 @@ func getPrismacloudVulnerabilityOverview(ctx context.Context, d *plugin.QueryDat
 		return nil, err
 	}
 
+	if err := waitForRateLimit(ctx, d, serviceVulnerability); err != nil {
+		return nil, err
+	}
+
 	vulnerability, err := api.GetVulnerabilityOverview(conn)
 	if err != nil {
 		plugin.Logger(ctx).Error("prismacloud_vulnerability_overview.getPrismacloudVulnerabilityOverview", "api_error", err)
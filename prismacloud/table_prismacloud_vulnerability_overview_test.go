@@ -0,0 +1,34 @@
+package prismacloud
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestListVulnerabilityOverview(t *testing.T) {
+	m := newMockServer(t)
+	m.handleFixture("GET", "/uve/api/v2/dashboard/vulnerabilities/overview", "vulnerability_overview.json")
+
+	rows := testQuery{
+		table:   "prismacloud_vulnerability_overview",
+		columns: []string{"total_vulnerable_runtime_assets", "total_vulnerabilitiesin_runtime", "values"},
+	}.run(t, m)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	var assets map[string]int
+	if err := json.Unmarshal(rows[0]["total_vulnerable_runtime_assets"].GetJsonValue(), &assets); err != nil {
+		t.Fatal(err)
+	}
+	if assets["totalCount"] != 120 || assets["hostCount"] != 30 {
+		t.Errorf("unexpected vulnerable runtime assets: %v", assets)
+	}
+	var values []map[string]interface{}
+	if err := json.Unmarshal(rows[0]["values"].GetJsonValue(), &values); err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 {
+		t.Errorf("expected 2 values, got %v", values)
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/paloaltonetworks/prisma-cloud-go/timerange"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/api"
 	"github.com/turbot/steampipe-plugin-prismacloud/prismacloud/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -38,6 +42,12 @@ func complianceBreakdownCommonFilterColumns(columns []*plugin.Column) []*plugin.
 			Type:        proto.ColumnType_STRING,
 			Transform:   transform.FromQual("cloud_region"),
 		},
+		{
+			Name:        "account_group",
+			Description: "The name of the account group of the compliance posture.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("account_group"),
+		},
 		{
 			Name:        "policy_compliance_standard_name",
 			Description: "The name of the compliance standard associated with the policy.",
@@ -65,6 +75,7 @@ func commonComplianceBreakdownKeyQualColumns() plugin.KeyColumnSlice {
 		{Name: "account_name", Require: plugin.Optional},
 		{Name: "cloud_type", Require: plugin.Optional},
 		{Name: "cloud_region", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
+		{Name: "account_group", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 		{Name: "policy_compliance_standard_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 		{Name: "policy_compliance_requirement_name", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
 		{Name: "policy_compliance_section_id", Require: plugin.Optional, CacheMatch: query_cache.CacheMatchExact},
@@ -93,6 +104,10 @@ func buildComplianceBreakdownStatisticQueryParameter(_ context.Context, d *plugi
 				if operator == "=" {
 					queryParameter["cloud.region"] = []string{fmt.Sprint(val)}
 				}
+			case "account_group":
+				if operator == "=" {
+					queryParameter["account.group"] = []string{fmt.Sprint(val)}
+				}
 			case "policy_compliance_standard_name":
 				if operator == "=" {
 					queryParameter["policy.complianceStandard"] = []string{fmt.Sprint(val)}
@@ -204,6 +219,132 @@ func buildVulnerabilityAssetsQueryParameter(_ context.Context, d *plugin.QueryDa
 	return queryParameter
 }
 
+// Quote a string literal for use in an RQL query
+func rqlQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// Build an RQL condition matching the attribute against one or more values
+func rqlCondition(attribute string, values []string) string {
+	if len(values) == 1 {
+		return fmt.Sprintf("%s = %s", attribute, rqlQuote(values[0]))
+	}
+
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = rqlQuote(v)
+	}
+	return fmt.Sprintf("%s IN (%s)", attribute, strings.Join(quoted, ", "))
+}
+
+// Returns the string values of the equality quals on a column, expanding IN lists
+func equalsQualStrings(d *plugin.QueryData, columnName string) []string {
+	if d.Quals[columnName] == nil {
+		return nil
+	}
+
+	var values []string
+	for _, q := range d.Quals[columnName].Quals {
+		if q.Operator != "=" {
+			continue
+		}
+		if list := q.Value.GetListValue(); list != nil {
+			for _, v := range list.Values {
+				values = append(values, v.GetStringValue())
+			}
+			continue
+		}
+		values = append(values, q.Value.GetStringValue())
+	}
+	return values
+}
+
+// Returns the start and end, in Unix milliseconds, of the time range matching the quals on a timestamp column.
+// Strict bounds are moved by one millisecond so both ends of the range are inclusive.
+// A zero start or end means that bound is not constrained by the quals.
+func timeRangeFromQuals(d *plugin.QueryData, columnName string) (int64, int64) {
+	start, end := int64(0), int64(0)
+	if d.Quals[columnName] == nil {
+		return start, end
+	}
+
+	lowerBound := func(t int64) {
+		start = max(start, t)
+	}
+	upperBound := func(t int64) {
+		if end == 0 || t < end {
+			end = t
+		}
+	}
+
+	for _, q := range d.Quals[columnName].Quals {
+		t := q.Value.GetTimestampValue().AsTime().UnixMilli()
+		switch q.Operator {
+		case "=":
+			lowerBound(t)
+			upperBound(t)
+		case ">=":
+			lowerBound(t)
+		case ">":
+			lowerBound(t + 1)
+		case "<=":
+			upperBound(t)
+		case "<":
+			upperBound(t - 1)
+		}
+	}
+
+	return start, end
+}
+
+// Returns the value of a timerange.TimeRange from the quals of a search.
+// Lower and upper bounds on the timestamp column are combined into an absolute range ending now when there is no upper bound,
+// otherwise the time_range qual or a range from epoch is used. Returns nil when the bounds don't overlap.
+func timeRangeValueFromQuals(d *plugin.QueryData, columnName string) (interface{}, error) {
+	start, end := timeRangeFromQuals(d, columnName)
+	if start == 0 && end == 0 {
+		if d.EqualsQualString("time_range") != "" {
+			return parseRelativeTimeRange(d.EqualsQualString("time_range"))
+		}
+		return timerange.Epoch, nil
+	}
+
+	if end == 0 {
+		end = time.Now().UnixMilli()
+	}
+	if start > end {
+		return nil, nil
+	}
+
+	return timerange.Absolute{Start: int(start), End: int(end)}, nil
+}
+
+// Returns the value of a timerange.TimeRange for a relative time range such as '24 hours' or '2 weeks',
+// or for a range up to now starting at 'epoch' or 'login'.
+func parseRelativeTimeRange(value string) (interface{}, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	switch v {
+	case timerange.Epoch, timerange.Login:
+		return v, nil
+	}
+
+	fields := strings.Fields(v)
+	if len(fields) == 2 {
+		amount, err := strconv.Atoi(fields[0])
+		unit := strings.TrimSuffix(fields[1], "s")
+		switch unit {
+		case timerange.Hour, timerange.Day, timerange.Week, timerange.Month, timerange.Year:
+			if err == nil && amount > 0 {
+				return timerange.Relative{Amount: amount, Unit: unit}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("invalid time range '%s', expected an amount of hours, days, weeks, months or years such as '24 hours', or 'epoch' or 'login'", value)
+}
+
 // Connection key quals
 // if the caching is required other than per connection, build a cache key for the call and use it in Memoize
 // since getCurrentUserProfile is a call, caching should be per connection